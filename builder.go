@@ -0,0 +1,53 @@
+package errx
+
+import "context"
+
+// Builder constructs an error step by step as an alternative to passing properties to New.
+// It is obtained via Build, and the error is constructed by calling Err.
+type Builder struct {
+	msg        string
+	properties []Property
+}
+
+// Build returns a Builder for an error with the given message.
+func Build(msg string) *Builder {
+	return &Builder{msg: msg}
+}
+
+// With adds the given properties to the error being built.
+func (b *Builder) With(properties ...Property) *Builder {
+	b.properties = append(b.properties, properties...)
+
+	return b
+}
+
+// HTTPCode sets the HTTP code of the error being built.
+func (b *Builder) HTTPCode(httpCode int) *Builder {
+	return b.With(WithHTTPCode(httpCode))
+}
+
+// CustomCode sets the custom code of the error being built.
+func (b *Builder) CustomCode(customCode int) *Builder {
+	return b.With(WithCustomCode(customCode))
+}
+
+// Context sets the context of the error being built.
+func (b *Builder) Context(ctx context.Context) *Builder {
+	return b.With(WithContext(ctx))
+}
+
+// Fields merges the given fields into the fields of the error being built.
+func (b *Builder) Fields(fields map[string]any) *Builder {
+	return b.With(WithFields(fields))
+}
+
+// Stack captures the call stack when Err is called.
+func (b *Builder) Stack() *Builder {
+	return b.With(WithStack())
+}
+
+// Err constructs the error.
+// It produces the same result as calling New with the message and the properties added so far.
+func (b *Builder) Err() error {
+	return New(b.msg, b.properties...)
+}
@@ -0,0 +1,53 @@
+package errx
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+func TestBuilderMatchesProperties(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "alice")
+	fields := map[string]any{"user_id": 1}
+
+	built := Build("not found").HTTPCode(404).CustomCode(1001).Context(ctx).Fields(fields).Err()
+	want := New("not found", WithHTTPCode(404), WithCustomCode(1001), WithContext(ctx), WithFields(fields))
+
+	got, ok := built.(*CustomError)
+	if !ok {
+		t.Fatalf("Err() is %T, want *CustomError", built)
+	}
+	wantErr := want.(*CustomError)
+
+	if got.Message != wantErr.Message || got.HTTPCode != wantErr.HTTPCode || got.CustomCode != wantErr.CustomCode {
+		t.Errorf("Err() = %+v, want %+v", got, wantErr)
+	}
+	if got.CTX != wantErr.CTX {
+		t.Errorf("CTX = %v, want %v", got.CTX, wantErr.CTX)
+	}
+	if !maps.Equal(got.Fields, wantErr.Fields) {
+		t.Errorf("Fields = %v, want %v", got.Fields, wantErr.Fields)
+	}
+	if !Equal(built, want) {
+		t.Error("Equal(built, want) = false, want true")
+	}
+}
+
+func TestBuilderWithoutProperties(t *testing.T) {
+	err := Build("boom").Err()
+
+	if _, ok := err.(*CustomError); ok {
+		t.Error("Err() without properties is a *CustomError, want the same plain error as New")
+	}
+	if err.Error() != "boom" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "boom")
+	}
+}
+
+func TestBuilderStack(t *testing.T) {
+	err := Build("boom").Stack().Err()
+
+	if !HasStack(err) {
+		t.Error("HasStack() = false, want true")
+	}
+}
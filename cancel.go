@@ -0,0 +1,122 @@
+package errx
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// deadlineRemainingField is the field under which WithDeadline records the time left before the deadline.
+const deadlineRemainingField = "deadline_remaining"
+
+// IsCanceled reports whether err originated from a cancelled context.
+// It is true if err's chain contains context.Canceled, or if the context attached to
+// any CustomError in the chain has been cancelled.
+func IsCanceled(err error) bool {
+	return isContextErr(err, context.Canceled)
+}
+
+// IsDeadlineExceeded reports whether err originated from a context whose deadline passed.
+// It is true if err's chain contains context.DeadlineExceeded, or if the context attached to
+// any CustomError in the chain has exceeded its deadline.
+func IsDeadlineExceeded(err error) bool {
+	return isContextErr(err, context.DeadlineExceeded)
+}
+
+// isContextErr reports whether err's chain, or the context attached to any CustomError in it,
+// matches the given context error.
+func isContextErr(err, target error) bool {
+	if Is(err, target) {
+		return true
+	}
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.CTX != nil && Is(customErr.CTX.Err(), target) {
+			return true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return false
+}
+
+// WithContextErr returns a Property that records the error of the context attached to an error,
+// if that context is already done, so that errors.Is and classifiers that do not inspect contexts,
+// such as Classify, recognize context.Canceled or context.DeadlineExceeded in the chain.
+// It must be applied after the context is set, for example after WithContext or on a layer
+// created by Wrap, which inherits the context of the wrapped error.
+// The context error becomes the base error if there is none. Otherwise, it is added alongside the
+// existing base error, which keeps its message and remains reachable through Is and As.
+// If the error is not a CustomError, or its context is not done, it is returned unchanged.
+func WithContextErr() Property {
+	return func(err error) error {
+		customErr, ok := AsCustom(err)
+		if !ok || customErr.CTX == nil {
+			return err
+		}
+
+		ctxErr := customErr.CTX.Err()
+		if ctxErr == nil || Is(customErr.base, ctxErr) {
+			return err
+		}
+
+		if customErr.base == nil {
+			customErr.base = ctxErr
+		} else {
+			customErr.base = &contextErrBase{base: customErr.base, ctxErr: ctxErr}
+		}
+
+		return err
+	}
+}
+
+// WithDeadline returns a Property that records how much time was left before the deadline of the
+// context attached to an error, as a time.Duration stored in the "deadline_remaining" field.
+// The duration is negative if the deadline had already passed, showing by how much it was overrun.
+// Like WithContextErr, it must be applied after the context is set.
+// If the error is not a CustomError, or its context has no deadline, it is returned unchanged.
+func WithDeadline() Property {
+	return withDeadlineFrom(time.Now)
+}
+
+// withDeadlineFrom is like WithDeadline but reads the current time from the given clock.
+func withDeadlineFrom(now func() time.Time) Property {
+	return func(err error) error {
+		customErr, ok := AsCustom(err)
+		if !ok || customErr.CTX == nil {
+			return err
+		}
+
+		deadline, ok := customErr.CTX.Deadline()
+		if !ok {
+			return err
+		}
+
+		return WithFields(map[string]any{deadlineRemainingField: deadline.Sub(now())})(err)
+	}
+}
+
+// contextErrBase is a base error that also carries the error of a done context.
+// It has the message of the original base error and unwraps to both errors.
+type contextErrBase struct {
+	base   error
+	ctxErr error
+}
+
+// Error returns the message of the original base error.
+func (e *contextErrBase) Error() string {
+	return e.base.Error()
+}
+
+// Cause returns the original base error, which allows github.com/pkg/errors.Cause to traverse it.
+func (e *contextErrBase) Cause() error {
+	return e.base
+}
+
+// Unwrap returns the original base error and the context error.
+func (e *contextErrBase) Unwrap() []error {
+	return []error{e.base, e.ctxErr}
+}
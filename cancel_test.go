@@ -0,0 +1,123 @@
+package errx
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+)
+
+func TestIsCanceled(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"wraps context.Canceled", Wrap(context.Canceled, "query aborted", WithHTTPCode(499)), true},
+		{"cancelled context", New("query aborted", WithContext(canceled)), true},
+		{"cancelled context on an inner layer", Wrap(New("query aborted", WithContext(canceled)), "outer"), true},
+		{"live context", New("query failed", WithContext(context.Background())), false},
+		{"plain error", errSentinel, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCanceled(tt.err); got != tt.want {
+				t.Errorf("IsCanceled() = %t, want %t", got, tt.want)
+			}
+			if IsDeadlineExceeded(tt.err) {
+				t.Error("IsDeadlineExceeded() = true, want false")
+			}
+		})
+	}
+}
+
+func TestIsDeadlineExceeded(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	if !IsDeadlineExceeded(Wrap(context.DeadlineExceeded, "slow query", WithHTTPCode(504))) {
+		t.Error("IsDeadlineExceeded() of a wrapped context.DeadlineExceeded = false, want true")
+	}
+	if !IsDeadlineExceeded(New("slow query", WithContext(expired))) {
+		t.Error("IsDeadlineExceeded() with an expired context = false, want true")
+	}
+}
+
+func TestWithContextErr(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"without base", New("query aborted", WithContext(canceled), WithContextErr())},
+		{"with base", Wrap(errSentinel, "query aborted", WithContext(canceled), WithContextErr())},
+		{"inherited context", Wrap(New("query aborted", WithContext(canceled)), "outer", WithContextErr())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !IsCanceled(tt.err) {
+				t.Error("IsCanceled() = false, want true")
+			}
+			if !stderrors.Is(tt.err, context.Canceled) {
+				t.Error("errors.Is(context.Canceled) = false, want true")
+			}
+		})
+	}
+
+	withBase := tests[1].err
+	if !Is(withBase, errSentinel) {
+		t.Error("Is() of the original base = false, want true")
+	}
+	if want := "sentinel: query aborted"; withBase.Error() != want {
+		t.Errorf("Error() = %q, want %q", withBase.Error(), want)
+	}
+
+	live := New("query failed", WithContext(context.Background()), WithContextErr())
+	if stderrors.Is(live, context.Canceled) {
+		t.Error("errors.Is(context.Canceled) with a live context = true, want false")
+	}
+	if got := WithContextErr()(errSentinel); got != errSentinel {
+		t.Errorf("WithContextErr() on a plain error = %v, want it unchanged", got)
+	}
+}
+
+func TestWithDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := New("slow query", WithContext(ctx), WithDeadline())
+
+	remaining, ok := Fields(err)[deadlineRemainingField].(time.Duration)
+	if !ok {
+		t.Fatalf("Fields() = %v, want a time.Duration under %q", Fields(err), deadlineRemainingField)
+	}
+	const tolerance = time.Second
+	if remaining > time.Minute || remaining < time.Minute-tolerance {
+		t.Errorf("remaining = %v, want within %v of %v", remaining, tolerance, time.Minute)
+	}
+
+	if live := New("query failed", WithContext(context.Background()), WithDeadline()); len(Fields(live)) != 0 {
+		t.Errorf("Fields() without a deadline = %v, want none", Fields(live))
+	}
+	if got := WithDeadline()(errSentinel); got != errSentinel {
+		t.Errorf("WithDeadline() on a plain error = %v, want it unchanged", got)
+	}
+}
+
+func TestWithDeadlineOverrun(t *testing.T) {
+	deadline := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	err := New("slow query", WithContext(ctx), withDeadlineFrom(func() time.Time { return deadline.Add(250 * time.Millisecond) }))
+	if got := Fields(err)[deadlineRemainingField]; got != -250*time.Millisecond {
+		t.Errorf("remaining = %v, want -250ms", got)
+	}
+}
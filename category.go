@@ -0,0 +1,75 @@
+package errx
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// CategoryHTTPCodes maps categories to the HTTP code that ResolveHTTPCode returns for errors of
+// that category when no explicit HTTP code is set, for example "validation" to 400.
+// It is empty by default and should be populated during initialization, as it is not safe for
+// concurrent modification.
+var CategoryHTTPCodes = map[string]int{}
+
+// WithCategory returns a Property that sets the category of an error.
+// Categories are broad classes such as "validation", "database" or "external" used to group errors.
+// If the error is a CustomError, it updates the Category of the existing error.
+// Otherwise, it creates a new CustomError with the specified category.
+func WithCategory(category string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Category = category
+
+			return err
+		}
+
+		return &CustomError{
+			Message:  err.Error(),
+			Category: category,
+		}
+	}
+}
+
+// Category returns the category of the outermost CustomError in err's chain that has one set.
+// The boolean result reports whether such a category was found.
+func Category(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Category != "" {
+			return customErr.Category, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
+
+// ResolveHTTPCode returns the HTTP code to report for err: the HTTP code set on its chain if
+// there is one, otherwise the code mapped to its category in CategoryHTTPCodes, and otherwise
+// 500 Internal Server Error.
+func ResolveHTTPCode(err error) int {
+	if httpCode, ok := mappedHTTPCode(err); ok {
+		return httpCode
+	}
+
+	return http.StatusInternalServerError
+}
+
+// mappedHTTPCode returns the HTTP code set on err's chain, or the code mapped to its category
+// in CategoryHTTPCodes. The boolean result reports whether either was found.
+func mappedHTTPCode(err error) (int, bool) {
+	if httpCode, ok := GetHTTPCode(err); ok {
+		return httpCode, true
+	}
+
+	if category, ok := Category(err); ok {
+		if httpCode, ok := CategoryHTTPCodes[category]; ok {
+			return httpCode, true
+		}
+	}
+
+	return 0, false
+}
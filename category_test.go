@@ -0,0 +1,66 @@
+package errx
+
+import "testing"
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   string
+		wantOK bool
+	}{
+		{"unset", New("boom", WithHTTPCode(500)), "", false},
+		{"set", New("invalid email", WithCategory("validation")), "validation", true},
+		{"inherited through wrap", Wrap(New("timeout", WithCategory("database")), "lookup failed"), "database", true},
+		{"overwritten by wrap", Wrap(New("timeout", WithCategory("database")), "payment failed", WithCategory("external")), "external", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Category(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Category() = (%q, %t), want (%q, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLogValueIncludesCategory(t *testing.T) {
+	err := New("timeout", WithCategory("database"))
+
+	for _, attr := range err.(*CustomError).LogValue().Group() {
+		if attr.Key == "category" {
+			if got := attr.Value.String(); got != "database" {
+				t.Errorf("category = %q, want %q", got, "database")
+			}
+
+			return
+		}
+	}
+
+	t.Error("LogValue() has no category")
+}
+
+func TestResolveHTTPCode(t *testing.T) {
+	CategoryHTTPCodes["validation"] = 400
+	defer delete(CategoryHTTPCodes, "validation")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"explicit code wins", New("conflict", WithCategory("validation"), WithHTTPCode(409)), 409},
+		{"category fallback", New("invalid email", WithCategory("validation")), 400},
+		{"unmapped category", New("timeout", WithCategory("database")), 500},
+		{"no code", errSentinel, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveHTTPCode(tt.err); got != tt.want {
+				t.Errorf("ResolveHTTPCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
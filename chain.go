@@ -0,0 +1,133 @@
+package errx
+
+import "github.com/pkg/errors"
+
+// maxChainDepth is the maximum number of layers traversed in an error chain,
+// which guards against cyclic chains.
+const maxChainDepth = 100
+
+// Root returns the deepest error in err's chain, following both Unwrap and Cause.
+// It stops after maxChainDepth layers to guard against cyclic chains.
+// It returns nil for a nil error.
+func Root(err error) error {
+	for range maxChainDepth {
+		next := unwrapOnce(err)
+		if next == nil {
+			return err
+		}
+
+		err = next
+	}
+
+	return err
+}
+
+// LayerInfo describes a single layer of an error chain.
+type LayerInfo struct {
+	Message    string
+	HTTPCode   int
+	CustomCode int
+	Code       string
+}
+
+// Flatten returns a description of each CustomError in err's chain, from the outermost to the
+// innermost. If the innermost CustomError wraps another error, a final layer holding the message
+// of that error is added, unless the innermost CustomError has an empty message, such as the layer
+// added by Enrich, in which case its layer holds the message of that error instead.
+// It stops after maxChainDepth layers to guard against cyclic chains.
+// It returns nil if err does not contain any CustomError.
+func Flatten(err error) []LayerInfo {
+	var result []LayerInfo
+
+	var customErr *CustomError
+	for len(result) < maxChainDepth && errors.As(err, &customErr) {
+		message, merged := layerMessage(customErr)
+		result = append(result, LayerInfo{
+			Message:    message,
+			HTTPCode:   customErr.HTTPCode,
+			CustomCode: customErr.CustomCode,
+			Code:       customErr.Code,
+		})
+
+		err = customErr.Unwrap()
+		if err != nil && !merged && !errors.As(err, new(*CustomError)) {
+			result = append(result, LayerInfo{Message: err.Error()})
+		}
+	}
+
+	return result
+}
+
+// unwrapOnce returns the error directly wrapped by err, or nil if there is none.
+// It prefers Unwrap over Cause when an error implements both.
+func unwrapOnce(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Cause() error }:
+		return e.Cause()
+	default:
+		return nil
+	}
+}
+
+// WalkChain calls fn for each error in err's chain, starting from the outermost one.
+// It follows both Unwrap() error and the Unwrap() []error form used by Join, visiting the
+// branches of a joined error in order, depth first. It stops as soon as fn returns false.
+func WalkChain(err error, fn func(error) bool) {
+	walk(err, fn, 0)
+}
+
+// walk calls fn for err and the errors it wraps, and reports whether the traversal should continue.
+func walk(err error, fn func(error) bool, depth int) bool {
+	if err == nil || depth >= maxChainDepth {
+		return true
+	}
+
+	if !fn(err) {
+		return false
+	}
+
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, branch := range multi.Unwrap() {
+			if !walk(branch, fn, depth+1) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return walk(unwrapOnce(err), fn, depth+1)
+}
+
+// ContainsType reports whether any error in err's chain is of type T, such as *CustomError or
+// *net.OpError, or implements T if T is an interface type.
+// Unlike errors.As, it does not extract the error, and it does not call any As methods.
+// It follows the same chain as WalkChain.
+func ContainsType[T error](err error) bool {
+	found := false
+	WalkChain(err, func(e error) bool {
+		_, found = e.(T)
+
+		return !found
+	})
+
+	return found
+}
+
+// AllCustom returns every CustomError in err's chain, from the outermost to the innermost,
+// including those wrapped by other error types. It follows the same chain as WalkChain.
+// It returns nil if err does not contain any CustomError.
+func AllCustom(err error) []*CustomError {
+	var result []*CustomError
+	WalkChain(err, func(e error) bool {
+		if customErr, ok := e.(*CustomError); ok && customErr != nil {
+			result = append(result, customErr)
+		}
+
+		return true
+	})
+
+	return result
+}
@@ -0,0 +1,216 @@
+package errx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestRoot(t *testing.T) {
+	err := Wrap(Wrap(Wrap(io.EOF, "read failed"), "parse failed", WithHTTPCode(400)), "request failed")
+
+	if got := Root(err); got != io.EOF {
+		t.Errorf("Root() = %v, want io.EOF", got)
+	}
+	if got := Root(io.EOF); got != io.EOF {
+		t.Errorf("Root(io.EOF) = %v, want io.EOF", got)
+	}
+	if got := Root(nil); got != nil {
+		t.Errorf("Root(nil) = %v, want nil", got)
+	}
+}
+
+// cyclicError is an error whose chain loops back to itself.
+type cyclicError struct{ next error }
+
+func (e *cyclicError) Error() string { return "cycle" }
+
+func (e *cyclicError) Unwrap() error { return e.next }
+
+func TestRootCycle(t *testing.T) {
+	err := &cyclicError{}
+	err.next = err
+
+	if got := Root(err); got != err {
+		t.Errorf("Root() = %v, want the cyclic error", got)
+	}
+}
+
+func TestWalkChainLinear(t *testing.T) {
+	middle := Wrap(io.EOF, "read failed", WithHTTPCode(500))
+	err := Wrap(middle, "request failed")
+
+	var got []error
+	WalkChain(err, func(e error) bool {
+		got = append(got, e)
+
+		return true
+	})
+
+	want := []error{err, middle, io.EOF}
+	if len(got) != len(want) {
+		t.Fatalf("WalkChain visited %d errors, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("visit %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWalkChainJoined(t *testing.T) {
+	first := Wrap(io.EOF, "first", WithHTTPCode(500))
+	second := New("second", WithHTTPCode(404))
+	joined := Join(first, second)
+	err := Wrap(joined, "batch failed")
+
+	var got []error
+	WalkChain(err, func(e error) bool {
+		got = append(got, e)
+
+		return true
+	})
+
+	want := []error{err, joined, first, io.EOF, second}
+	if len(got) != len(want) {
+		t.Fatalf("WalkChain visited %d errors, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("visit %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWalkChainStopsEarly(t *testing.T) {
+	err := Wrap(Wrap(io.EOF, "inner", WithHTTPCode(500)), "outer")
+
+	visits := 0
+	WalkChain(err, func(error) bool {
+		visits++
+
+		return false
+	})
+
+	if visits != 1 {
+		t.Errorf("WalkChain visited %d errors after fn returned false, want 1", visits)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	err := Wrap(Wrap(New("db down", WithHTTPCode(503), WithCode("db.unavailable")), "query failed", WithCustomCode(1001)),
+		"request failed", WithHTTPCode(500))
+
+	want := []LayerInfo{
+		{Message: "request failed", HTTPCode: 500, CustomCode: 1001, Code: "db.unavailable"},
+		{Message: "query failed", HTTPCode: 503, CustomCode: 1001, Code: "db.unavailable"},
+		{Message: "db down", HTTPCode: 503, Code: "db.unavailable"},
+	}
+
+	got := Flatten(err)
+	if len(got) != len(want) {
+		t.Fatalf("Flatten() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("layer %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFlattenPlainBase(t *testing.T) {
+	got := Flatten(Wrap(io.EOF, "read failed", WithHTTPCode(500)))
+
+	want := []LayerInfo{{Message: "read failed", HTTPCode: 500}, {Message: "EOF"}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Flatten() = %+v, want %+v", got, want)
+	}
+	if got := Flatten(io.EOF); got != nil {
+		t.Errorf("Flatten() of a plain error = %+v, want nil", got)
+	}
+}
+
+func TestFlattenEnriched(t *testing.T) {
+	got := Flatten(Wrap(Enrich(io.EOF, WithHTTPCode(500)), "read failed"))
+
+	want := []LayerInfo{{Message: "read failed", HTTPCode: 500}, {Message: "EOF", HTTPCode: 500}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Flatten() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFlattenCycle(t *testing.T) {
+	customErr := NewCustom("cycle")
+	customErr.base = customErr
+
+	if got := len(Flatten(customErr)); got != maxChainDepth {
+		t.Errorf("Flatten() of a cyclic chain returned %d layers, want %d", got, maxChainDepth)
+	}
+}
+
+func TestContainsType(t *testing.T) {
+	withCustom := fmt.Errorf("outer: %w", Wrap(io.EOF, "read failed", WithHTTPCode(500)))
+	if !ContainsType[*CustomError](withCustom) {
+		t.Error("ContainsType[*CustomError]() = false, want true")
+	}
+
+	withoutCustom := fmt.Errorf("outer: %w", &fs.PathError{Op: "open", Path: "x", Err: io.EOF})
+	if ContainsType[*CustomError](withoutCustom) {
+		t.Error("ContainsType[*CustomError]() of a chain without one = true, want false")
+	}
+	if !ContainsType[*fs.PathError](withoutCustom) {
+		t.Error("ContainsType[*fs.PathError]() = false, want true")
+	}
+	if ContainsType[*CustomError](nil) {
+		t.Error("ContainsType[*CustomError](nil) = true, want false")
+	}
+}
+
+func TestAllCustom(t *testing.T) {
+	inner := New("inner", WithHTTPCode(503))
+	middle := Wrap(inner, "middle", WithCustomCode(1001))
+	outer := Wrap(middle, "outer")
+
+	got := AllCustom(outer)
+	if len(got) != 3 || got[0] != outer || got[1] != middle || got[2] != inner {
+		t.Errorf("AllCustom() = %v, want the three layers from outer to inner", got)
+	}
+
+	mixed := fmt.Errorf("handler: %w", Wrap(fmt.Errorf("repo: %w", inner), "service"))
+	got = AllCustom(mixed)
+	if len(got) != 2 || got[0].Message != "service" || got[1] != inner {
+		t.Errorf("AllCustom() of a mixed chain = %v, want the two CustomErrors", got)
+	}
+
+	if got := AllCustom(io.EOF); got != nil {
+		t.Errorf("AllCustom() of a plain error = %v, want nil", got)
+	}
+}
+
+func TestPkgErrorsCauseReachesRoot(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"custom layers", Wrap(Wrap(errSentinel, "query failed", WithHTTPCode(500)), "lookup failed", WithCustomCode(1001))},
+		{"plain and custom layers", Wrap(Wrap(Wrap(errSentinel, "read failed"), "parse failed", WithHTTPCode(400)), "request failed")},
+		{"pkg errors layer", Wrap(errors.Wrap(Wrap(errSentinel, "query failed", WithHTTPCode(500)), "retry failed"), "lookup failed")},
+		{"context error base", Wrap(Wrap(errSentinel, "query aborted", WithContext(canceled), WithContextErr()), "lookup failed")},
+		{"validation error", Wrap(NewValidation(map[string]string{"email": "required"}, WithBase(errSentinel)), "signup failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Cause(tt.err); got != errSentinel {
+				t.Errorf("errors.Cause() = %v, want the sentinel", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package errx
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Classify enriches common errors of the standard library with an HTTP code and a retryable flag.
+// It recognizes the following errors anywhere in err's chain:
+//   - fs.ErrNotExist, which os.ErrNotExist is, and sql.ErrNoRows as 404 Not Found
+//   - context.DeadlineExceeded as a retryable 504 Gateway Timeout
+//   - a net.Error reporting a timeout as a retryable 503 Service Unavailable
+//
+// A recognized error is wrapped via Wrap with the reason phrase of its HTTP code as the message,
+// so it remains reachable through Is and As. Errors that are not recognized, or that already have
+// an HTTP code set, are returned unchanged.
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := GetHTTPCode(err); ok {
+		return err
+	}
+
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, sql.ErrNoRows):
+		return classified(err, http.StatusNotFound, false)
+	case errors.Is(err, context.DeadlineExceeded):
+		return classified(err, http.StatusGatewayTimeout, true)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return classified(err, http.StatusServiceUnavailable, true)
+	default:
+		return err
+	}
+}
+
+// classified wraps err with the given HTTP code and retryable flag.
+func classified(err error, httpCode int, retryable bool) error {
+	return Wrap(err, http.StatusText(httpCode), WithHTTPCode(httpCode), WithRetryable(retryable))
+}
@@ -0,0 +1,63 @@
+package errx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+)
+
+// timeoutError is a net.Error that reports a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"not exist", fmt.Errorf("open config: %w", os.ErrNotExist), 404, false},
+		{"no rows", sql.ErrNoRows, 404, false},
+		{"deadline", context.DeadlineExceeded, 504, true},
+		{"net timeout", &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}, 503, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Classify(tt.err)
+
+			if code, _ := GetHTTPCode(err); code != tt.wantCode {
+				t.Errorf("GetHTTPCode() = %d, want %d", code, tt.wantCode)
+			}
+			if got := IsRetryable(err); got != tt.wantRetryable {
+				t.Errorf("IsRetryable() = %t, want %t", got, tt.wantRetryable)
+			}
+			if !Is(err, tt.err) {
+				t.Error("Classify() dropped the original error")
+			}
+		})
+	}
+}
+
+func TestClassifyUnchanged(t *testing.T) {
+	if got := Classify(errSentinel); got != errSentinel {
+		t.Errorf("Classify() of an unknown error = %v, want it unchanged", got)
+	}
+
+	coded := Wrap(sql.ErrNoRows, "lookup", WithHTTPCode(500))
+	if got := Classify(coded); got != coded {
+		t.Errorf("Classify() of an error with an HTTP code = %v, want it unchanged", got)
+	}
+	if Classify(nil) != nil {
+		t.Error("Classify(nil) is not nil")
+	}
+}
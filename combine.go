@@ -0,0 +1,123 @@
+package errx
+
+import (
+	"context"
+	"net/http"
+)
+
+// Combine returns a CustomError whose attributes come from primary, falling back to those of
+// secondary for any attribute that primary leaves unset. Fields, headers and tags are merged,
+// with the fields and headers of primary taking precedence. Unlike Wrap, it does not make
+// secondary a cause of the result: the result keeps the base error of primary.
+// If primary does not contain a CustomError, the result is a CustomError with an empty message
+// and primary as its base error, like the one created by Enrich, so that primary remains
+// reachable through Is and As.
+// If either error is nil, the other one is returned unchanged.
+func Combine(primary, secondary error) error {
+	if primary == nil {
+		return secondary
+	}
+
+	if secondary == nil {
+		return primary
+	}
+
+	result, ok := AsCustom(primary)
+	if ok {
+		result = result.Clone()
+	} else {
+		result = newLayer(primary, "")
+	}
+
+	if result.HTTPCode == 0 {
+		result.HTTPCode, _ = GetHTTPCode(secondary)
+	}
+
+	if result.CustomCode == 0 {
+		result.CustomCode, _ = GetCustomCode(secondary)
+	}
+
+	if result.Code == "" {
+		result.Code, _ = Code(secondary)
+	}
+
+	if result.CTX == nil || result.CTX == context.Background() {
+		result.CTX, _ = Context(secondary)
+	}
+
+	if !result.Retryable {
+		result.Retryable = IsRetryable(secondary)
+	}
+
+	if result.Attempt == 0 {
+		result.Attempt, _ = Attempt(secondary)
+	}
+
+	if result.Severity == 0 {
+		result.Severity, _ = GetSeverity(secondary)
+	}
+
+	if result.Timestamp.IsZero() {
+		result.Timestamp, _ = CreatedAt(secondary)
+	}
+
+	if result.RequestID == "" {
+		result.RequestID, _ = RequestID(secondary)
+	}
+
+	if result.TraceID == "" {
+		result.TraceID, _ = TraceID(secondary)
+	}
+
+	if result.SpanID == "" {
+		result.SpanID, _ = SpanID(secondary)
+	}
+
+	if result.Category == "" {
+		result.Category, _ = Category(secondary)
+	}
+
+	if result.Hint == "" {
+		result.Hint, _ = Hint(secondary)
+	}
+
+	if result.Reason == "" {
+		result.Reason, _ = Reason(secondary)
+	}
+
+	if result.Component == "" {
+		result.Component, _ = Component(secondary)
+	}
+
+	if result.Source == "" {
+		result.Source, _ = Source(secondary)
+	}
+
+	for key, value := range Fields(secondary) {
+		if result.Fields == nil {
+			result.Fields = make(map[string]any)
+		}
+
+		if _, ok := result.Fields[key]; !ok {
+			result.Fields[key] = value
+		}
+	}
+
+	for key, values := range HTTPHeaders(secondary) {
+		if result.Headers == nil {
+			result.Headers = make(http.Header)
+		}
+
+		if _, ok := result.Headers[key]; !ok {
+			result.Headers[key] = values
+		}
+	}
+
+	result.Tags = mergeTags(result.Tags, Tags(secondary))
+
+	if len(Details(result)) == 0 {
+		result.Details = Details(secondary)
+	}
+
+	return result
+}
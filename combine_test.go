@@ -0,0 +1,70 @@
+package errx
+
+import (
+	stderrors "errors"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCombineFallback(t *testing.T) {
+	primary := New("payment failed", WithHTTPCode(502), WithTags("billing"),
+		WithFields(map[string]any{"order_id": 7, "region": "eu"}))
+	secondary := Wrap(errSentinel, "gateway timeout",
+		WithHTTPCode(504), WithCustomCode(3001), WithCode("gateway.timeout"), WithCategory("external"),
+		WithRetryable(true), WithTags("transient"), WithFields(map[string]any{"region": "us", "gateway": "stripe"}))
+
+	err := Combine(primary, secondary)
+	result, ok := err.(*CustomError)
+	if !ok {
+		t.Fatalf("Combine() is %T, want *CustomError", err)
+	}
+
+	if result.Message != "payment failed" || result.HTTPCode != 502 {
+		t.Errorf("message and HTTP code = %q %d, want those of primary", result.Message, result.HTTPCode)
+	}
+	if result.CustomCode != 3001 || result.Code != "gateway.timeout" || result.Category != "external" {
+		t.Errorf("fallback codes = %d %q %q, want those of secondary", result.CustomCode, result.Code, result.Category)
+	}
+	if !result.Retryable {
+		t.Error("Retryable = false, want the flag of secondary")
+	}
+	if want := []string{"billing", "transient"}; !slices.Equal(result.Tags, want) {
+		t.Errorf("Tags = %v, want %v", result.Tags, want)
+	}
+
+	wantFields := map[string]any{"order_id": 7, "region": "eu", "gateway": "stripe"}
+	if !maps.Equal(result.Fields, wantFields) {
+		t.Errorf("Fields = %v, want %v", result.Fields, wantFields)
+	}
+
+	if Is(err, errSentinel) {
+		t.Error("Combine() made secondary a cause of the result")
+	}
+	if primary.(*CustomError).CustomCode != 0 {
+		t.Error("Combine() modified primary")
+	}
+}
+
+func TestCombineNil(t *testing.T) {
+	err := New("boom", WithHTTPCode(500))
+
+	if Combine(err, nil) != err || Combine(nil, err) != err {
+		t.Error("Combine() with a nil error did not return the other one")
+	}
+}
+
+func TestCombinePlainPrimary(t *testing.T) {
+	err := Combine(io.EOF, New("read failed", WithHTTPCode(500), WithRetryable(true)))
+
+	if !Is(err, io.EOF) || !stderrors.Is(err, io.EOF) {
+		t.Error("Is(io.EOF) = false, want true")
+	}
+	if err.Error() != io.EOF.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), io.EOF.Error())
+	}
+	if code, _ := GetHTTPCode(err); code != 500 || !IsRetryable(err) {
+		t.Errorf("GetHTTPCode() = %d and IsRetryable() = %t, want the attributes of secondary", code, IsRetryable(err))
+	}
+}
@@ -0,0 +1,125 @@
+package errx
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+)
+
+// Equal reports whether a and b are equivalent errors.
+// Two CustomErrors are equal when their messages, HTTP codes, custom codes, codes and fields match
+// and their base errors are equal in turn. Any other errors are equal when their messages match.
+// The context, stack and other internal state are ignored.
+func Equal(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	customA, okA := a.(*CustomError)
+	customB, okB := b.(*CustomError)
+	if !okA || !okB {
+		return okA == okB && a.Error() == b.Error()
+	}
+
+	return customA.Message == customB.Message &&
+		customA.HTTPCode == customB.HTTPCode &&
+		customA.CustomCode == customB.CustomCode &&
+		customA.Code == customB.Code &&
+		reflect.DeepEqual(customA.Fields, customB.Fields) &&
+		Equal(customA.base, customB.base)
+}
+
+// Diff returns a description of how got differs from want, one difference per line, meant for
+// reporting failed comparisons in tests. It covers the attributes compared by Equal: the messages,
+// HTTP codes, custom codes, codes and fields of CustomErrors, and the messages of other errors,
+// following the base errors of both chains. Differences in base errors are prefixed with "base.".
+// It returns an empty string when the errors are equal according to Equal.
+func Diff(want, got error) string {
+	if Equal(want, got) {
+		return ""
+	}
+
+	var diffs []string
+	diffErrors(&diffs, "", want, got, 0)
+
+	return strings.Join(diffs, "\n")
+}
+
+// diffErrors appends the differences between want and got to diffs, prefixing each with the given path.
+func diffErrors(diffs *[]string, path string, want, got error, depth int) {
+	if depth >= maxChainDepth {
+		return
+	}
+
+	if want == nil || got == nil {
+		if want != got {
+			*diffs = append(*diffs, fmt.Sprintf("%serror: want %s, got %s", path, diffError(want), diffError(got)))
+		}
+
+		return
+	}
+
+	customWant, okWant := want.(*CustomError)
+	customGot, okGot := got.(*CustomError)
+	if !okWant || !okGot {
+		if okWant != okGot {
+			*diffs = append(*diffs, fmt.Sprintf("%stype: want %T, got %T", path, want, got))
+		}
+
+		if want.Error() != got.Error() {
+			*diffs = append(*diffs, fmt.Sprintf("%smessage: want %q, got %q", path, want.Error(), got.Error()))
+		}
+
+		return
+	}
+
+	if customWant.Message != customGot.Message {
+		*diffs = append(*diffs, fmt.Sprintf("%smessage: want %q, got %q", path, customWant.Message, customGot.Message))
+	}
+
+	if customWant.HTTPCode != customGot.HTTPCode {
+		*diffs = append(*diffs, fmt.Sprintf("%shttp_code: want %d, got %d", path, customWant.HTTPCode, customGot.HTTPCode))
+	}
+
+	if customWant.CustomCode != customGot.CustomCode {
+		*diffs = append(*diffs, fmt.Sprintf("%scustom_code: want %d, got %d", path, customWant.CustomCode, customGot.CustomCode))
+	}
+
+	if customWant.Code != customGot.Code {
+		*diffs = append(*diffs, fmt.Sprintf("%scode: want %q, got %q", path, customWant.Code, customGot.Code))
+	}
+
+	keys := make(map[string]any, len(customWant.Fields)+len(customGot.Fields))
+	maps.Copy(keys, customWant.Fields)
+	maps.Copy(keys, customGot.Fields)
+
+	for _, key := range sortedKeys(keys) {
+		wantValue, wantOK := customWant.Fields[key]
+		gotValue, gotOK := customGot.Fields[key]
+		if wantOK != gotOK || !reflect.DeepEqual(wantValue, gotValue) {
+			*diffs = append(*diffs, fmt.Sprintf("%sfields[%q]: want %s, got %s",
+				path, key, diffField(wantValue, wantOK), diffField(gotValue, gotOK)))
+		}
+	}
+
+	diffErrors(diffs, path+"base.", customWant.base, customGot.base, depth+1)
+}
+
+// diffError returns the form of err used by Diff.
+func diffError(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("%q", err.Error())
+}
+
+// diffField returns the form of a field value used by Diff.
+func diffField(value any, ok bool) string {
+	if !ok {
+		return "<unset>"
+	}
+
+	return fmt.Sprintf("%#v", value)
+}
@@ -0,0 +1,78 @@
+package errx
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "alice")
+	newErr := func(httpCode int, properties ...Property) error {
+		return Wrap(stderrors.New("no rows"), "user not found",
+			append([]Property{WithHTTPCode(httpCode), WithCustomCode(1001), WithFields(map[string]any{"user_id": 7})}, properties...)...)
+	}
+
+	tests := []struct {
+		name string
+		a, b error
+		want bool
+	}{
+		{"equal", newErr(404), newErr(404), true},
+		{"context and stack ignored", newErr(404, WithContext(ctx), WithStack()), newErr(404), true},
+		{"different code", newErr(404), newErr(500), false},
+		{"nested equal", Wrap(newErr(404), "outer", WithCode("x")), Wrap(newErr(404), "outer", WithCode("x")), true},
+		{"nested different base", Wrap(newErr(404), "outer", WithCode("x")), Wrap(newErr(500), "outer", WithCode("x")), false},
+		{"different fields", newErr(404, WithFields(map[string]any{"user_id": 8})), newErr(404), false},
+		{"plain errors", stderrors.New("boom"), stderrors.New("boom"), true},
+		{"custom and plain", NewCustom("boom"), stderrors.New("boom"), false},
+		{"nil", nil, nil, true},
+		{"nil and error", nil, errSentinel, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.want {
+				t.Errorf("Equal() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	newErr := func(httpCode int, fields map[string]any) error {
+		return Wrap(stderrors.New("no rows"), "user not found", WithHTTPCode(httpCode), WithCustomCode(1001), WithFields(fields))
+	}
+
+	tests := []struct {
+		name      string
+		want, got error
+		diff      string
+	}{
+		{"equal", newErr(404, map[string]any{"user_id": 7}), newErr(404, map[string]any{"user_id": 7}), ""},
+		{
+			"codes and fields",
+			newErr(404, map[string]any{"user_id": 7, "region": "eu"}),
+			newErr(500, map[string]any{"user_id": 8}),
+			"http_code: want 404, got 500\n" +
+				`fields["region"]: want "eu", got <unset>` + "\n" +
+				`fields["user_id"]: want 7, got 8`,
+		},
+		{
+			"base",
+			Wrap(newErr(404, nil), "outer"),
+			Wrap(Wrap(stderrors.New("timeout"), "user not found", WithHTTPCode(404), WithCustomCode(1001)), "outer"),
+			`base.base.message: want "no rows", got "timeout"`,
+		},
+		{"custom and plain", NewCustom("boom"), stderrors.New("boom"), "type: want *errx.CustomError, got *errors.errorString"},
+		{"nil", nil, errSentinel, `error: want <nil>, got "sentinel"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.want, tt.got); got != tt.diff {
+				t.Errorf("Diff() = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
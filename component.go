@@ -0,0 +1,81 @@
+package errx
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// WithComponent returns a Property that sets the component of an error, which is the layer of
+// the application that produced it, such as "repository", "service" or "handler".
+// If the error is a CustomError, it updates the Component of the existing error.
+// Otherwise, it creates a new CustomError with the specified component.
+func WithComponent(component string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Component = component
+
+			return err
+		}
+
+		return &CustomError{
+			Message:   err.Error(),
+			Component: component,
+		}
+	}
+}
+
+// Component returns the component of the outermost CustomError in err's chain that has one set.
+// The boolean result reports whether such a component was found.
+func Component(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Component != "" {
+			return customErr.Component, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
+
+// ComponentErrorer creates and wraps errors tagged with a fixed component, so that each layer of
+// an application can declare its component once, as in
+// var errs = errx.NewComponentErrorer("repository").
+type ComponentErrorer struct {
+	component string
+}
+
+// NewComponentErrorer returns a ComponentErrorer that tags errors with the given component.
+func NewComponentErrorer(component string) ComponentErrorer {
+	return ComponentErrorer{component: component}
+}
+
+// New is like the New function, but always returns a CustomError tagged with the component
+// of the ComponentErrorer. The given properties are applied after the component is set.
+func (c ComponentErrorer) New(msg string, properties ...Property) error {
+	return New(msg, c.properties(properties)...)
+}
+
+// Newf is like New with a message formatted according to the given format specifier.
+func (c ComponentErrorer) Newf(format string, args ...any) error {
+	return c.New(fmt.Sprintf(format, args...))
+}
+
+// Wrap is like the Wrap function, but always returns a CustomError tagged with the component
+// of the ComponentErrorer. The given properties are applied after the component is set.
+func (c ComponentErrorer) Wrap(err error, msg string, properties ...Property) error {
+	return Wrap(err, msg, c.properties(properties)...)
+}
+
+// Wrapf is like Wrap with a message formatted according to the given format specifier.
+func (c ComponentErrorer) Wrapf(err error, format string, args ...any) error {
+	return c.Wrap(err, fmt.Sprintf(format, args...))
+}
+
+// properties returns the property setting the component followed by the given properties.
+func (c ComponentErrorer) properties(properties []Property) []Property {
+	return append([]Property{WithComponent(c.component)}, properties...)
+}
@@ -0,0 +1,62 @@
+package errx
+
+import "testing"
+
+func TestComponentErrorer(t *testing.T) {
+	errs := NewComponentErrorer("repository")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"New", errs.New("not found", WithHTTPCode(404)), "not found"},
+		{"Newf", errs.Newf("user %d not found", 42), "user 42 not found"},
+		{"Wrap", errs.Wrap(errSentinel, "query failed"), "sentinel: query failed"},
+		{"Wrapf", errs.Wrapf(errSentinel, "query %s failed", "users"), "sentinel: query users failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.err.(*CustomError); !ok {
+				t.Fatalf("%v is not a CustomError", tt.err)
+			}
+			if got, ok := Component(tt.err); got != "repository" || !ok {
+				t.Errorf("Component() = (%q, %t), want (\"repository\", true)", got, ok)
+			}
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	overridden := errs.New("not found", WithComponent("cache"))
+	if got, _ := Component(overridden); got != "cache" {
+		t.Errorf("Component() with an explicit component = %q, want %q", got, "cache")
+	}
+}
+
+func TestComponentThroughWrap(t *testing.T) {
+	inner := New("not found", WithComponent("repository"))
+
+	tests := []struct {
+		name   string
+		err    error
+		want   string
+		wantOK bool
+	}{
+		{"unset", New("boom", WithHTTPCode(500)), "", false},
+		{"inner only", Wrap(inner, "lookup failed"), "repository", true},
+		{"outer wins", Wrap(inner, "lookup failed", WithComponent("service")), "service", true},
+		{"plain error", errSentinel, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Component(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Component() = (%q, %t), want (%q, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package errx
+
+import "github.com/pkg/errors"
+
+// WithRequestID returns a Property that sets the ID of the request during which an error occurred.
+// If the error is a CustomError, it updates the RequestID of the existing error.
+// Otherwise, it creates a new CustomError with the specified request ID.
+func WithRequestID(requestID string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.RequestID = requestID
+
+			return err
+		}
+
+		return &CustomError{
+			Message:   err.Error(),
+			RequestID: requestID,
+		}
+	}
+}
+
+// RequestID returns the request ID of the first CustomError in err's chain that has one set.
+// The boolean result reports whether such a request ID was found.
+func RequestID(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.RequestID != "" {
+			return customErr.RequestID, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
+
+// WithTraceID returns a Property that sets the ID of the distributed trace during which an error occurred.
+// If the error is a CustomError, it updates the TraceID of the existing error.
+// Otherwise, it creates a new CustomError with the specified trace ID.
+func WithTraceID(traceID string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.TraceID = traceID
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			TraceID: traceID,
+		}
+	}
+}
+
+// TraceID returns the trace ID of the first CustomError in err's chain that has one set.
+// The boolean result reports whether such a trace ID was found.
+func TraceID(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.TraceID != "" {
+			return customErr.TraceID, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
+
+// WithSpanID returns a Property that sets the ID of the trace span during which an error occurred.
+// If the error is a CustomError, it updates the SpanID of the existing error.
+// Otherwise, it creates a new CustomError with the specified span ID.
+func WithSpanID(spanID string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.SpanID = spanID
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			SpanID:  spanID,
+		}
+	}
+}
+
+// SpanID returns the span ID of the first CustomError in err's chain that has one set.
+// The boolean result reports whether such a span ID was found.
+func SpanID(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.SpanID != "" {
+			return customErr.SpanID, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
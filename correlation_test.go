@@ -0,0 +1,64 @@
+package errx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDOuterWins(t *testing.T) {
+	inner := New("inner", WithRequestID("inner-id"))
+
+	tests := []struct {
+		name   string
+		err    error
+		want   string
+		wantOK bool
+	}{
+		{"unset", New("boom", WithHTTPCode(500)), "", false},
+		{"inner only", Wrap(inner, "outer"), "inner-id", true},
+		{"outer wins", Wrap(inner, "outer", WithRequestID("outer-id")), "outer-id", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RequestID(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RequestID() = (%q, %t), want (%q, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTraceAndSpanIDThroughWrap(t *testing.T) {
+	inner := New("inner", WithTraceID("trace-1"), WithSpanID("span-1"))
+	err := Wrap(inner, "outer", WithSpanID("span-2"))
+
+	if got, ok := TraceID(err); got != "trace-1" || !ok {
+		t.Errorf("TraceID() = (%q, %t), want (\"trace-1\", true)", got, ok)
+	}
+	if got, ok := SpanID(err); got != "span-2" || !ok {
+		t.Errorf("SpanID() = (%q, %t), want (\"span-2\", true)", got, ok)
+	}
+
+	data, _ := json.Marshal(err)
+	if !strings.Contains(string(data), `"trace_id":"trace-1","span_id":"span-2"`) {
+		t.Errorf("json.Marshal() = %s, want the trace and span IDs", data)
+	}
+}
+
+func TestTraceAndSpanIDUnset(t *testing.T) {
+	err := New("boom", WithHTTPCode(500))
+
+	if got, ok := TraceID(err); got != "" || ok {
+		t.Errorf("TraceID() = (%q, %t), want (\"\", false)", got, ok)
+	}
+	if got, ok := SpanID(err); got != "" || ok {
+		t.Errorf("SpanID() = (%q, %t), want (\"\", false)", got, ok)
+	}
+
+	data, _ := json.Marshal(err)
+	if strings.Contains(string(data), "trace_id") || strings.Contains(string(data), "span_id") {
+		t.Errorf("json.Marshal() = %s, want no trace or span ID", data)
+	}
+}
@@ -0,0 +1,36 @@
+package errx
+
+import (
+	"slices"
+	"sync"
+)
+
+var (
+	defaultPropertiesMu sync.RWMutex
+	defaultProperties   []Property
+)
+
+// SetDefaultProperties sets the properties that New and Wrap apply to every error they create,
+// before the properties given to the call, so that those can override the defaults.
+// It replaces any previously set defaults, and calling it with no properties removes them,
+// which allows tests to restore the default behavior.
+// It is safe for concurrent use, but it is meant to be called once during initialization,
+// as errors created concurrently may or may not see the new defaults.
+func SetDefaultProperties(properties ...Property) {
+	defaultPropertiesMu.Lock()
+	defer defaultPropertiesMu.Unlock()
+
+	defaultProperties = slices.Clone(properties)
+}
+
+// withDefaults returns the default properties followed by the given properties.
+func withDefaults(properties []Property) []Property {
+	defaultPropertiesMu.RLock()
+	defer defaultPropertiesMu.RUnlock()
+
+	if len(defaultProperties) == 0 {
+		return properties
+	}
+
+	return append(slices.Clone(defaultProperties), properties...)
+}
@@ -0,0 +1,61 @@
+package errx
+
+import (
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSetDefaultProperties(t *testing.T) {
+	SetDefaultProperties(WithFields(map[string]any{"service": "billing"}), WithHTTPCode(500))
+	defer SetDefaultProperties()
+
+	err := New("boom")
+	if Fields(err)["service"] != "billing" {
+		t.Errorf("Fields() = %v, want the default field", Fields(err))
+	}
+	if code, _ := GetHTTPCode(err); code != 500 {
+		t.Errorf("GetHTTPCode() = %d, want the default 500", code)
+	}
+
+	overridden := New("not found", WithHTTPCode(404))
+	if code, _ := GetHTTPCode(overridden); code != 404 {
+		t.Errorf("GetHTTPCode() with an explicit code = %d, want 404", code)
+	}
+
+	wrapped := Wrap(errSentinel, "lookup failed")
+	if Fields(wrapped)["service"] != "billing" {
+		t.Errorf("Fields() of a wrapped error = %v, want the default field", Fields(wrapped))
+	}
+}
+
+func TestSetDefaultPropertiesPlainWrap(t *testing.T) {
+	SetDefaultProperties(WithFields(map[string]any{"service": "billing"}))
+	defer SetDefaultProperties()
+
+	err := Wrap(io.EOF, "read config")
+	if got := err.Error(); got != "read config: EOF" {
+		t.Errorf("Error() = %q, want %q", got, "read config: EOF")
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Error("errors.Is(err, io.EOF) = false, want true")
+	}
+	if Fields(err)["service"] != "billing" {
+		t.Errorf("Fields() = %v, want the default field", Fields(err))
+	}
+
+	outer := Wrap(err, "start")
+	if got := outer.Error(); got != "read config: EOF: start" {
+		t.Errorf("Error() of an outer wrap = %q, want %q", got, "read config: EOF: start")
+	}
+}
+
+func TestSetDefaultPropertiesReset(t *testing.T) {
+	SetDefaultProperties(WithHTTPCode(500))
+	SetDefaultProperties()
+
+	if _, ok := New("boom").(*CustomError); ok {
+		t.Error("New() after resetting the defaults returned a CustomError, want a plain error")
+	}
+}
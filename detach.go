@@ -0,0 +1,192 @@
+package errx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Detach returns an equivalent copy of err in which the context of every CustomError is reset
+// to context.Background, so that the error no longer keeps request-scoped values alive.
+// It should be called before storing an error beyond the lifetime of its request.
+// The whole chain is walked, including the errors joined via Join and the errors wrapped by other
+// packages through an Unwrap method, such as the errors returned by fmt.Errorf with %w.
+// Errors that wrap a CustomError are copied, and errors of other packages are replaced by
+// an error with the same message that unwraps to the copies; Is and As still match the original
+// error itself. Parts of the chain without a CustomError are shared with the original.
+// The original error is left unchanged, and a nil error is returned as nil.
+func Detach(err error) error {
+	result, _ := detach(err)
+
+	return result
+}
+
+// detach implements Detach. The boolean result reports whether the chain of err has a CustomError,
+// in which case the returned error is a copy; otherwise, err itself is returned.
+func detach(err error) (error, bool) {
+	switch e := err.(type) {
+	case *CustomError:
+		if e == nil {
+			return err, false
+		}
+
+		clone := e.Clone()
+		clone.CTX = context.Background()
+		clone.base, _ = detach(e.base)
+
+		return clone, true
+	case *ValidationError:
+		if e == nil {
+			return err, false
+		}
+
+		clone := *e
+		if e.CustomError != nil {
+			clone.CustomError, _ = Detach(e.CustomError).(*CustomError)
+		}
+
+		return &clone, true
+	case *MultiError:
+		errs, ok := detachAll(e.errs)
+		if !ok {
+			return err, false
+		}
+
+		return &MultiError{errs: errs}, true
+	case *contextErrBase:
+		base, ok := detach(e.base)
+		if !ok {
+			return err, false
+		}
+
+		return &contextErrBase{base: base, ctxErr: e.ctxErr}, true
+	case *wrapError:
+		cause, ok := detach(e.cause)
+		if !ok {
+			return err, false
+		}
+
+		clone := *e
+		clone.cause = cause
+
+		return &clone, true
+	case interface{ Unwrap() error }:
+		cause, ok := detach(e.Unwrap())
+		if !ok {
+			return err, false
+		}
+
+		return &detachedWrapper{detachedOriginal: detachedOriginal{original: err}, cause: cause}, true
+	case interface{ Unwrap() []error }:
+		errs, ok := detachAll(e.Unwrap())
+		if !ok {
+			return err, false
+		}
+
+		return &detachedJoin{detachedOriginal: detachedOriginal{original: err}, errs: errs}, true
+	default:
+		return err, false
+	}
+}
+
+// detachAll detaches each of the given errors.
+// The boolean result reports whether any of them has a CustomError, in which case the returned
+// slice is a copy; otherwise, errs itself is returned.
+func detachAll(errs []error) ([]error, bool) {
+	var result []error
+	for i, err := range errs {
+		detached, ok := detach(err)
+		if !ok {
+			continue
+		}
+
+		if result == nil {
+			result = append([]error(nil), errs...)
+		}
+
+		result[i] = detached
+	}
+
+	if result == nil {
+		return errs, false
+	}
+
+	return result, true
+}
+
+// detachedOriginal holds an error of another package replaced by Detach.
+// It has the message and formatting of the original error, and Is and As match the original
+// error itself, without traversing its chain, which still holds the original contexts.
+type detachedOriginal struct {
+	original error
+}
+
+// Error returns the message of the original error.
+func (e *detachedOriginal) Error() string {
+	return e.original.Error()
+}
+
+// Is reports whether target is the original error.
+func (e *detachedOriginal) Is(target error) bool {
+	return reflect.TypeOf(e.original).Comparable() && e.original == target
+}
+
+// As sets target to the original error if it is assignable to it.
+func (e *detachedOriginal) As(target any) bool {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return false
+	}
+
+	if elem := value.Elem(); reflect.TypeOf(e.original).AssignableTo(elem.Type()) {
+		elem.Set(reflect.ValueOf(e.original))
+
+		return true
+	}
+
+	return false
+}
+
+// Format formats the original error.
+func (e *detachedOriginal) Format(s fmt.State, verb rune) {
+	if formatter, ok := e.original.(fmt.Formatter); ok {
+		formatter.Format(s, verb)
+
+		return
+	}
+
+	switch verb {
+	case 'v', 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// detachedWrapper replaces an error of another package wrapping a single error.
+type detachedWrapper struct {
+	detachedOriginal
+	cause error
+}
+
+// Cause returns the detached wrapped error, which allows github.com/pkg/errors.Cause to traverse it.
+func (e *detachedWrapper) Cause() error {
+	return e.cause
+}
+
+// Unwrap returns the detached wrapped error.
+func (e *detachedWrapper) Unwrap() error {
+	return e.cause
+}
+
+// detachedJoin replaces an error of another package wrapping several errors.
+type detachedJoin struct {
+	detachedOriginal
+	errs []error
+}
+
+// Unwrap returns the detached wrapped errors.
+func (e *detachedJoin) Unwrap() []error {
+	return e.errs
+}
@@ -0,0 +1,137 @@
+package errx
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestDetach(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("request"), "large payload")
+	inner := New("query failed", WithContext(ctx), WithHTTPCode(503), WithFields(map[string]any{"table": "users"}))
+	err := Wrap(Wrap(errSentinel, "db", WithContext(ctx)), "lookup failed", WithBase(inner), WithCustomCode(1001))
+
+	detached := Detach(err)
+
+	if _, ok := Context(detached); ok {
+		t.Error("Context() after Detach = true, want false")
+	}
+	if code, _ := GetHTTPCode(detached); code != 503 {
+		t.Errorf("GetHTTPCode() = %d, want 503", code)
+	}
+	if code, _ := GetCustomCode(detached); code != 1001 {
+		t.Errorf("GetCustomCode() = %d, want 1001", code)
+	}
+	if Fields(detached)["table"] != "users" {
+		t.Errorf("Fields() = %v, want the original fields", Fields(detached))
+	}
+	if detached.Error() != err.Error() {
+		t.Errorf("Error() = %q, want %q", detached.Error(), err.Error())
+	}
+
+	if got, ok := Context(err); !ok || got != ctx {
+		t.Error("Detach() modified the context of the original error")
+	}
+}
+
+func TestDetachValidationError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("request"), "r-1")
+	err := NewValidation(map[string]string{"email": "is invalid"}, WithContext(ctx))
+
+	detached := Detach(err)
+	if _, ok := Context(detached); ok {
+		t.Error("Context() after Detach = true, want false")
+	}
+	if fields, ok := FieldErrors(detached); !ok || fields["email"] != "is invalid" {
+		t.Errorf("FieldErrors() = (%v, %t), want the original field errors", fields, ok)
+	}
+	if Detach(nil) != nil {
+		t.Error("Detach(nil) is not nil")
+	}
+}
+
+func TestDetachWrappedChains(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("request"), "large payload")
+	ce := New("query failed", WithContext(ctx), WithHTTPCode(503))
+	other := stderrors.New("other")
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"fmt.Errorf", fmt.Errorf("lookup: %w", ce)},
+		{"Join", Join(ce, other)},
+		{"WithCauses", New("batch failed", WithCauses(ce))},
+		{"stdlib Join", stderrors.Join(other, ce)},
+		{"Wrap of a plain wrapper", Wrap(fmt.Errorf("lookup: %w", ce), "outer")},
+		{"WithContextErr", New("canceled", WithBase(fmt.Errorf("lookup: %w", ce)), WithContext(canceledContext()), WithContextErr())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detached := Detach(tt.err)
+			if _, ok := Context(detached); ok {
+				t.Error("Context() after Detach = true, want false")
+			}
+
+			var customErr *CustomError
+			for _, err := range chainOf(detached) {
+				if errors.As(err, &customErr) && customErr.CTX != context.Background() {
+					t.Fatalf("a CustomError in the chain of Detach() kept its context")
+				}
+			}
+			if detached.Error() != tt.err.Error() {
+				t.Errorf("Error() = %q, want %q", detached.Error(), tt.err.Error())
+			}
+			if code, _ := GetHTTPCode(detached); code != 503 {
+				t.Errorf("GetHTTPCode() = %d, want 503", code)
+			}
+			if got, ok := Context(ce); !ok || got != ctx {
+				t.Error("Detach() modified the context of the original error")
+			}
+		})
+	}
+}
+
+func TestDetachKeepsPlainChains(t *testing.T) {
+	plain := fmt.Errorf("lookup: %w", errSentinel)
+	if Detach(plain) != plain {
+		t.Error("Detach() of a chain without a CustomError is not the original error")
+	}
+
+	detached := Detach(fmt.Errorf("lookup: %w", Wrap(errSentinel, "db", WithContext(context.Background()))))
+	if !errors.Is(detached, errSentinel) {
+		t.Error("errors.Is(detached, errSentinel) = false, want true")
+	}
+	if got := fmt.Sprintf("%v", detached); got != "lookup: sentinel: db" {
+		t.Errorf("%%v = %q, want %q", got, "lookup: sentinel: db")
+	}
+}
+
+// chainOf returns err and every error reachable from it through Unwrap.
+func chainOf(err error) []error {
+	result := []error{err}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := e.Unwrap(); cause != nil {
+			result = append(result, chainOf(cause)...)
+		}
+	case interface{ Unwrap() []error }:
+		for _, cause := range e.Unwrap() {
+			result = append(result, chainOf(cause)...)
+		}
+	}
+
+	return result
+}
+
+// canceledContext returns a context that is already canceled.
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	return ctx
+}
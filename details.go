@@ -0,0 +1,39 @@
+package errx
+
+import "github.com/pkg/errors"
+
+// WithDetails returns a Property that attaches typed detail payloads to an error, such as a
+// description of the quota that was exceeded.
+// If the error is a CustomError, the details are appended to its existing details.
+// Otherwise, it creates a new CustomError with the specified details.
+func WithDetails(details ...any) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Details = append(customErr.Details, details...)
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Details: append([]any(nil), details...),
+		}
+	}
+}
+
+// Details collects the details of every CustomError in err's chain, from the outermost layer
+// to the innermost one, each layer's details in the order they were attached.
+// It returns nil if no details are set.
+func Details(err error) []any {
+	var result []any
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		result = append(result, customErr.Details...)
+
+		err = customErr.Unwrap()
+	}
+
+	return result
+}
@@ -0,0 +1,42 @@
+package errx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type quotaDetail struct {
+	Limit int `json:"limit"`
+}
+
+func (d quotaDetail) MarshalJSON() ([]byte, error) {
+	type plain quotaDetail
+
+	return json.Marshal(plain(d))
+}
+
+type retryDetail struct {
+	After string
+}
+
+func TestDetailsThroughWrap(t *testing.T) {
+	err := Wrap(New("quota exceeded", WithDetails(quotaDetail{Limit: 10})), "upload failed",
+		WithDetails(retryDetail{After: "1m"}))
+
+	got := Details(err)
+	if len(got) != 2 {
+		t.Fatalf("Details() = %v, want two details", got)
+	}
+	if got[0] != (retryDetail{After: "1m"}) || got[1] != (quotaDetail{Limit: 10}) {
+		t.Errorf("Details() = %v, want the outer detail first", got)
+	}
+
+	data, _ := json.Marshal(err)
+	if want := `{"message":"upload failed","cause":"quota exceeded","details":[{"limit":10}]}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	if got := Details(New("boom", WithHTTPCode(500))); got != nil {
+		t.Errorf("Details() without details = %v, want nil", got)
+	}
+}
@@ -2,8 +2,14 @@ package errx
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"github.com/pkg/errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
 )
 
 type Property func(err error) error
@@ -12,36 +18,158 @@ type CustomError struct {
 	base       error
 	Message    string
 	HTTPCode   int
+	Headers    http.Header
 	CustomCode int
+	Code       string
 	CTX        context.Context
+	Fields     map[string]any
+	Retryable  bool
+	Attempt    int
+	Severity   Severity
+	Timestamp  time.Time
+	RequestID  string
+	TraceID    string
+	SpanID     string
+	Category   string
+	Tags       []string
+	Details    []any
+	Hint       string
+	Reason     string
+	Source     string
+	Component  string
+	stack      []uintptr
+	caller     uintptr
+	maxLen     *int
+	noStack    bool
+	prefixes   []string
+
+	sensitiveFields []string
+	values          map[any]any
+
+	// template is the format the message was built from by Newf, Wrapf or Wrapw, if any.
+	template string
+	// causeLast reports whether Error renders the message before the message of the base error,
+	// like errors.Wrap does, rather than after it.
+	causeLast bool
 }
 
+// MessageSeparator is the separator Error places between the message of the base error and
+// the message of the CustomError. It defaults to ": ".
+// Messages are not escaped, so code that splits the result on the separator should use one that
+// does not appear in the messages themselves, such as " -> ".
+// It should be configured at init time, as it is not safe for concurrent modification.
+var MessageSeparator = ": "
+
 // Error returns a formatted string representation of the CustomError.
 // It concatenates the error message from the base error (if available)
-// with the message of the CustomError itself, separated by a colon.
-func (e CustomError) Error() string {
-	baseErrMsg := ""
+// with the message of the CustomError itself, separated by MessageSeparator.
+// If there is no base error, it returns just the message, and if the message is empty, such as
+// for an error enriched via Enrich, it returns just the message of the base error.
+// Layers that render like errors.Wrap, such as those created by Wrapf over other errors than
+// CustomErrors, place the message before the message of the base error instead.
+// The message is preceded by any prefixes set via WithPrefix, and the result is truncated
+// according to MaxMessageLen or WithTruncate.
+// It returns an empty string for a nil CustomError.
+func (e *CustomError) Error() string {
+	if e == nil {
+		return ""
+	}
+
+	msg := e.renderedMessage()
 	if e.base != nil {
-		baseErrMsg = e.base.Error()
+		switch {
+		case e.Message == "":
+			msg = e.renderedPrefixes() + e.base.Error()
+		case e.causeLast:
+			msg = msg + MessageSeparator + e.base.Error()
+		default:
+			msg = e.base.Error() + MessageSeparator + msg
+		}
 	}
-	return fmt.Sprintf("%s: %s", baseErrMsg, e.Message)
+	return truncate(msg, e.messageLimit())
 }
 
 // Cause returns the underlying base error of the CustomError.
 // It provides access to the original error that caused the CustomError.
-func (e CustomError) Cause() error {
+// It returns nil for a nil CustomError.
+func (e *CustomError) Cause() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.base
+}
+
+// Unwrap returns the underlying base error of the CustomError.
+// It allows the standard library errors.Is and errors.As to traverse the chain.
+// It returns nil for a nil CustomError.
+func (e *CustomError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+
 	return e.base
 }
 
+// Is reports whether the CustomError matches the target error.
+// It matches when the target is the same CustomError, which allows package-level CustomErrors
+// to be used as sentinel errors, or when the target is a CustomError with the same non-zero
+// custom code or the same non-empty code, which allows sentinels to be identified by their code.
+// It also matches ServerError and ClientError according to the HTTP code of the CustomError.
+func (e *CustomError) Is(target error) bool {
+	if class, ok := target.(*httpCodeClass); ok && e != nil {
+		return class.matches(e)
+	}
+
+	targetErr, ok := target.(*CustomError)
+	if e == nil || !ok || targetErr == nil {
+		return false
+	}
+
+	if e == targetErr {
+		return true
+	}
+
+	if targetErr.CustomCode != 0 && e.CustomCode == targetErr.CustomCode {
+		return true
+	}
+
+	return targetErr.Code != "" && e.Code == targetErr.Code
+}
+
+// Clone returns a copy of the CustomError that can be modified without affecting the original.
+// The Fields map, the Headers, the Tags, the Details and the values attached via WithValue are
+// copied, but the field values, details and attached values are not.
+// The base error and the context are shared with the original, as they are treated as immutable.
+// It returns nil for a nil CustomError.
+func (e *CustomError) Clone() *CustomError {
+	if e == nil {
+		return nil
+	}
+
+	clone := *e
+	clone.Fields = maps.Clone(e.Fields)
+	clone.Headers = e.Headers.Clone()
+	clone.Tags = slices.Clone(e.Tags)
+	clone.Details = slices.Clone(e.Details)
+	clone.sensitiveFields = slices.Clone(e.sensitiveFields)
+	clone.prefixes = slices.Clone(e.prefixes)
+	clone.values = maps.Clone(e.values)
+
+	return &clone
+}
+
 // New creates a new error with the given message and applies the given properties.
 // If no properties are given, it will simply return a wrapped error with the given message.
 // Otherwise, it will apply the properties to the error and return the modified error.
+// Any properties set via SetDefaultProperties are applied first.
 func New(msg string, properties ...Property) error {
+	properties = withDefaults(properties)
 	if len(properties) == 0 {
 		return errors.New(msg)
 	}
 
-	var result error = CustomError{
+	var result error = &CustomError{
 		Message: msg,
 		CTX:     context.Background(),
 	}
@@ -50,32 +178,219 @@ func New(msg string, properties ...Property) error {
 		result = property(result)
 	}
 
+	notifyError(result)
+
 	return result
 }
 
+// NewCustom creates a new CustomError with the given message and returns it as the concrete type,
+// so that its exported fields can be set directly instead of through properties.
+// Unlike New, it always returns a CustomError, even when no attributes are set.
+func NewCustom(msg string) *CustomError {
+	return &CustomError{
+		Message: msg,
+		CTX:     context.Background(),
+	}
+}
+
 // Wrap wraps the given error with the given message and applies the given properties.
-// If the given error is a CustomError, it wraps the error and applies the properties.
-// Otherwise, it uses errors.Wrap to wrap the error with the given message.
+// If the given error is a CustomError, it wraps the error, carries forward its HTTP code,
+// custom code, code and context, and then applies the properties, which may override them.
+// Any properties set via SetDefaultProperties are applied first.
+// If the given error is not a CustomError and there are no properties to apply,
+// it wraps the error with the given message and a stack exactly like errors.Wrap does,
+// but with a single allocation. If only properties set via SetDefaultProperties apply, the
+// result is a CustomError that still renders like errors.Wrap, with the message before the
+// message of the wrapped error.
 func Wrap(err error, msg string, properties ...Property) error {
 	if err == nil {
 		return nil
 	}
 
-	var customErr CustomError
-	if errors.As(err, &customErr) {
-		var result error = &CustomError{
-			base:    customErr,
-			Message: msg,
+	_, isCustom := AsCustom(err)
+	explicit := len(properties)
+	properties = withDefaults(properties)
+
+	if isCustom || len(properties) > 0 {
+		result := newLayer(err, msg)
+		result.causeLast = !isCustom && explicit == 0
+
+		return applyProperties(result, properties)
+	}
+
+	return newWrapError(err, msg)
+}
+
+// Enrich applies the given properties to err without wrapping it, so that attributes such as
+// fields or codes can be added without introducing a new layer in the chain.
+// Properties act on the first CustomError in err's chain in place, which is modified; errors that
+// are shared, such as sentinel errors, should be wrapped via Wrap instead.
+// If err does not contain a CustomError, it is first wrapped in a CustomError with an empty message
+// and err as its base error, so that err remains reachable through Is and As and the result has
+// the same message as err.
+// It returns nil for a nil error.
+func Enrich(err error, properties ...Property) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := AsCustom(err); !ok {
+		err = newLayer(err, "")
+	}
+
+	for _, property := range properties {
+		err = property(err)
+	}
+
+	return err
+}
+
+// newLayer returns a CustomError with the given message wrapping err.
+// If err is or wraps a CustomError, the new layer carries forward its HTTP code, custom code,
+// code and context.
+func newLayer(err error, msg string) *CustomError {
+	result := &CustomError{
+		base:    err,
+		Message: msg,
+		CTX:     context.Background(),
+	}
+
+	if customErr, ok := AsCustom(err); ok {
+		result.HTTPCode = customErr.HTTPCode
+		result.CustomCode = customErr.CustomCode
+		result.Code = customErr.Code
+		result.CTX = customErr.CTX
+	}
+
+	return result
+}
+
+// Newf creates a new error with a message formatted according to the given format specifier.
+// It is like calling New with the formatted message and no properties, but always returns a
+// CustomError, which records the format as the template of the message for Fingerprint.
+// If no properties are set via SetDefaultProperties, it records the stack like errors.New does.
+func Newf(format string, args ...any) error {
+	result := &CustomError{
+		Message:  fmt.Sprintf(format, args...),
+		CTX:      context.Background(),
+		template: format,
+	}
+
+	properties := withDefaults(nil)
+	if len(properties) == 0 {
+		result.stack = callers()
+	}
+
+	return applyProperties(result, properties)
+}
+
+// Wrapf wraps the given error with a message formatted according to the given format specifier.
+// It is like calling Wrap with the formatted message and no properties, but always returns a
+// CustomError, which records the format as the template of the message for Fingerprint.
+// If the given error is not a CustomError, the result renders like the error returned by
+// errors.Wrapf, with the message before the message of the wrapped error, and if no properties
+// are set via SetDefaultProperties, it records the stack like errors.Wrapf does.
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	result := newLayer(err, fmt.Sprintf(format, args...))
+	result.template = format
+
+	properties := withDefaults(nil)
+	if _, ok := AsCustom(err); !ok {
+		result.causeLast = true
+		if len(properties) == 0 {
+			result.stack = callers()
+		}
+	}
+
+	return applyProperties(result, properties)
+}
+
+// applyProperties applies the given properties to the given new error and reports it to the
+// hook set via SetErrorHook.
+func applyProperties(err *CustomError, properties []Property) error {
+	var result error = err
+	for _, property := range properties {
+		result = property(result)
+	}
+
+	notifyError(result)
+
+	return result
+}
+
+// Wrapw wraps the given error with a message formatted like Wrapf, using the fmt.Errorf idiom
+// to mark the position of the wrapped error: the first %w verb in the format stands for err,
+// for example Wrapw(err, "%w: loading %s", name).
+// The %w verb, the ": " separator next to it and the brackets or quotes enclosing it, as in
+// "loading %s (%w)", are left out of the message, as Error already prefixes the message with
+// the wrapped error, and args must not include err.
+// Like Wrapf, it returns a CustomError that records the format, without the %w verb, as the
+// template of the message for Fingerprint. Its base error is err, whose attributes are carried
+// forward as with Wrap, and it applies any properties set via SetDefaultProperties.
+func Wrapw(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	if before, after, ok := cutWrapVerb(format); ok {
+		format = removeWrapVerb(before, after)
+	}
+
+	result := newLayer(err, fmt.Sprintf(format, args...))
+	result.template = format
+
+	return applyProperties(result, withDefaults(nil))
+}
+
+// cutWrapVerb slices the format around its first %w verb, skipping escaped percent signs.
+// The boolean result reports whether the format has a %w verb.
+func cutWrapVerb(format string) (before, after string, found bool) {
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
 		}
 
-		for _, property := range properties {
-			result = property(result)
+		if format[i+1] == 'w' {
+			return format[:i], format[i+2:], true
 		}
 
-		return result
+		i++
+	}
+
+	return format, "", false
+}
+
+// wrapVerbEnclosures maps the opening characters that may enclose a %w verb to their closing ones.
+var wrapVerbEnclosures = map[byte]byte{'(': ')', '[': ']', '{': '}', '<': '>', '"': '"', '\'': '\''}
+
+// removeWrapVerb joins the parts of a format around its %w verb, leaving out the brackets or quotes
+// enclosing the verb and the separators and spaces next to it.
+// The parts are joined with the ": " separator if one was next to the verb, and with a space otherwise.
+func removeWrapVerb(before, after string) string {
+	if len(before) > 0 && len(after) > 0 && wrapVerbEnclosures[before[len(before)-1]] == after[0] {
+		before, after = before[:len(before)-1], after[1:]
+	}
+
+	before = strings.TrimRight(before, " ")
+	after = strings.TrimLeft(after, " ")
+
+	trimmedBefore := strings.TrimRight(strings.TrimSuffix(before, ":"), " ")
+	trimmedAfter := strings.TrimLeft(strings.TrimPrefix(after, ":"), " ")
+
+	separator := " "
+	if trimmedBefore != before || trimmedAfter != after {
+		separator = ": "
+	}
+
+	if trimmedBefore == "" || trimmedAfter == "" {
+		return trimmedBefore + trimmedAfter
 	}
 
-	return errors.Wrap(err, msg)
+	return trimmedBefore + separator + trimmedAfter
 }
 
 // WithHTTPCode returns a Property that sets the HTTP code of an error.
@@ -83,14 +398,35 @@ func Wrap(err error, msg string, properties ...Property) error {
 // Otherwise, it creates a new CustomError with the specified HTTP code.
 func WithHTTPCode(httpCode int) Property {
 	return func(err error) error {
-		var customErr CustomError
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.HTTPCode = httpCode
+
+			return err
+		}
+
+		return &CustomError{
+			Message:  err.Error(),
+			HTTPCode: httpCode,
+		}
+	}
+}
+
+// WithHTTPCodeAndText returns a Property that sets the HTTP code of an error like WithHTTPCode,
+// and also sets the message to the standard reason phrase of the code if the message is empty.
+func WithHTTPCodeAndText(httpCode int) Property {
+	return func(err error) error {
+		var customErr *CustomError
 		if errors.As(err, &customErr) {
 			customErr.HTTPCode = httpCode
+			if customErr.Message == "" {
+				customErr.Message = http.StatusText(httpCode)
+			}
 
-			return customErr
+			return err
 		}
 
-		return CustomError{
+		return &CustomError{
 			Message:  err.Error(),
 			HTTPCode: httpCode,
 		}
@@ -102,43 +438,285 @@ func WithHTTPCode(httpCode int) Property {
 // Otherwise, it creates a new CustomError with the specified custom code.
 func WithCustomCode(customCode int) Property {
 	return func(err error) error {
-		var customErr CustomError
+		var customErr *CustomError
 		if errors.As(err, &customErr) {
 			customErr.CustomCode = customCode
 
-			return customErr
+			return err
 		}
 
-		return CustomError{
+		return &CustomError{
 			Message:    err.Error(),
 			CustomCode: customCode,
 		}
 	}
 }
 
+// WithCode returns a Property that sets the code of an error.
+// Codes are namespaced strings such as "orders.payment_declined" that avoid collisions between teams.
+// If the error is a CustomError, it updates the Code of the existing error.
+// Otherwise, it creates a new CustomError with the specified code.
+func WithCode(code string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Code = code
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Code:    code,
+		}
+	}
+}
+
 // WithContext returns a Property that sets the context of an error.
 // If the error is a CustomError, it updates the CTX of the existing error.
 // Otherwise, it creates a new CustomError with the specified context.
 func WithContext(ctx context.Context) Property {
 	return func(err error) error {
-		var customErr CustomError
+		var customErr *CustomError
 		if errors.As(err, &customErr) {
 			customErr.CTX = ctx
 
-			return customErr
+			return err
 		}
 
-		return CustomError{
+		return &CustomError{
 			Message: err.Error(),
 			CTX:     ctx,
 		}
 	}
 }
 
-// Is reports whether any error in err's chain has the same value as target.
-// This function is a wrapper around pkg/errors.Is.
-func Is(err, target error) bool { return errors.Is(err, target) }
+// WithReplaceMessage returns a Property that replaces the message of an error in place,
+// keeping its codes, fields, base error and every other attribute, for example to rephrase
+// a message for clients. Unlike Wrap, it does not add a layer to the chain.
+// If the error is a CustomError, it updates the Message of the existing error.
+// Otherwise, it creates a new CustomError with the specified message.
+func WithReplaceMessage(msg string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Message = msg
+			customErr.template = ""
 
-// As reports whether the error err satisfies the predicate target.
-// This function is a wrapper around pkg/errors.As.
-func As(err error, target any) bool { return errors.As(err, target) }
+			return err
+		}
+
+		return &CustomError{
+			Message: msg,
+		}
+	}
+}
+
+// AsCustom returns the first CustomError in err's chain.
+// The boolean result reports whether such an error was found.
+// It follows the same rules as As, but walks the chain itself, which avoids the allocation of
+// the target of As in the common case of errors that do not implement an As method.
+func AsCustom(err error) (*CustomError, bool) {
+	return asCustom(err, 0)
+}
+
+// asCustom returns the first CustomError in err's chain, which is found at the given depth of
+// the chain of the error passed to AsCustom. It stops after maxChainDepth layers to guard against
+// cyclic chains.
+func asCustom(err error, depth int) (*CustomError, bool) {
+	for ; err != nil && depth < maxChainDepth; depth++ {
+		if customErr, ok := err.(*CustomError); ok {
+			return customErr, customErr != nil
+		}
+
+		if matcher, ok := err.(interface{ As(any) bool }); ok {
+			var customErr *CustomError
+			if matcher.As(&customErr) {
+				return customErr, true
+			}
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, branch := range e.Unwrap() {
+				if customErr, ok := asCustom(branch, depth+1); ok {
+					return customErr, true
+				}
+			}
+
+			return nil, false
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// WithBase returns a Property that sets the base error of an error, replacing any existing one.
+// It allows attaching a cause to an error created via New without wrapping it.
+// If the error is a CustomError, it updates the base error of the existing error.
+// Otherwise, it creates a new CustomError with the specified base error.
+func WithBase(base error) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.base = base
+
+			return err
+		}
+
+		return &CustomError{
+			base:    base,
+			Message: err.Error(),
+		}
+	}
+}
+
+// GetHTTPCode returns the HTTP code of the first CustomError in err's chain that has one set.
+// The boolean result reports whether such a code was found.
+func GetHTTPCode(err error) (int, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.HTTPCode != 0 {
+			return customErr.HTTPCode, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return 0, false
+}
+
+// GetCustomCode returns the custom code of the first CustomError in err's chain that has one set.
+// A custom code of 0 is treated as unset, so the boolean result is false when no layer carries
+// a non-zero code.
+func GetCustomCode(err error) (int, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.CustomCode != 0 {
+			return customErr.CustomCode, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return 0, false
+}
+
+// HasHTTPCode reports whether any CustomError in err's chain carries the given HTTP code.
+// An HTTP code of 0 is treated as unset and never matches.
+func HasHTTPCode(err error, httpCode int) bool {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if httpCode != 0 && customErr.HTTPCode == httpCode {
+			return true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return false
+}
+
+// HasCustomCode reports whether any CustomError in err's chain carries the given custom code.
+// A custom code of 0 is treated as unset and never matches.
+func HasCustomCode(err error, customCode int) bool {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customCode != 0 && customErr.CustomCode == customCode {
+			return true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return false
+}
+
+// Code returns the code of the first CustomError in err's chain that has one set.
+// The boolean result reports whether such a code was found.
+func Code(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Code != "" {
+			return customErr.Code, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
+
+// IsCode reports whether any CustomError in err's chain carries the given code.
+// It is a shorthand for matching against a sentinel error with the same code.
+// An empty code is treated as unset and never matches.
+func IsCode(err error, code string) bool {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if code != "" && customErr.Code == code {
+			return true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return false
+}
+
+// Message returns the message of the first CustomError in err's chain, without the base error's message.
+// If err is not and does not wrap a CustomError, it returns err.Error().
+// It returns an empty string for a nil error.
+func Message(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Message
+	}
+
+	return err.Error()
+}
+
+// Context returns the context of the first CustomError in err's chain that has a context
+// other than context.Background set.
+// If no such context is found, it returns context.Background and false.
+func Context(err error) (context.Context, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.CTX != nil && customErr.CTX != context.Background() {
+			return customErr.CTX, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return context.Background(), false
+}
+
+// Is reports whether any error in err's tree matches target.
+// This function is a wrapper around the standard library errors.Is, so it also
+// traverses the Unwrap() []error form used by Join.
+func Is(err, target error) bool { return stderrors.Is(err, target) }
+
+// As finds the first error in err's tree that matches target, and if one is found, sets target to that error value.
+// This function is a wrapper around the standard library errors.As, so it also
+// traverses the Unwrap() []error form used by Join.
+func As(err error, target any) bool { return stderrors.As(err, target) }
+
+// AsType finds the first error in err's tree that matches the type T, such as *CustomError or
+// *fs.PathError, and returns it. The boolean result reports whether such an error was found.
+// It follows the same rules as As, without the need to declare a target variable.
+func AsType[T error](err error) (T, bool) {
+	var target T
+	if stderrors.As(err, &target) {
+		return target, true
+	}
+
+	return target, false
+}
@@ -0,0 +1,696 @@
+package errx
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var errSentinel = stderrors.New("sentinel")
+
+func TestUnwrapFindsSentinel(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"wrap", Wrap(errSentinel, "outer", WithHTTPCode(500))},
+		{"nested wrap", Wrap(Wrap(errSentinel, "inner", WithCustomCode(7)), "outer")},
+		{"base", New("outer", WithBase(errSentinel))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !stderrors.Is(tt.err, errSentinel) {
+				t.Errorf("errors.Is(%q, errSentinel) = false, want true", tt.err)
+			}
+
+			customErr, ok := tt.err.(*CustomError)
+			if !ok {
+				t.Fatalf("err is %T, want *CustomError", tt.err)
+			}
+			if customErr.Unwrap() == nil {
+				t.Error("Unwrap() = nil, want the base error")
+			}
+		})
+	}
+}
+
+func TestWrapWithHTTPCodeKeepsBase(t *testing.T) {
+	inner := New("inner", WithCustomCode(42))
+
+	err := Wrap(inner, "outer", WithHTTPCode(500))
+
+	customErr, ok := err.(*CustomError)
+	if !ok {
+		t.Fatalf("err is %T, want *CustomError", err)
+	}
+	if customErr.Cause() != inner {
+		t.Errorf("Cause() = %v, want the wrapped CustomError", customErr.Cause())
+	}
+	if customErr.HTTPCode != 500 {
+		t.Errorf("HTTPCode = %d, want 500", customErr.HTTPCode)
+	}
+	if customErr.CustomCode != 42 {
+		t.Errorf("CustomCode = %d, want 42", customErr.CustomCode)
+	}
+	if got, want := err.Error(), "inner: outer"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	var target *CustomError
+	if !As(err, &target) || target != customErr {
+		t.Error("As did not find the outer *CustomError")
+	}
+}
+
+func TestGetHTTPCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   int
+		wantOK bool
+	}{
+		{"plain error", stderrors.New("plain"), 0, false},
+		{"single", New("single", WithHTTPCode(404)), 404, true},
+		{"outer layer only", Wrap(New("inner", WithCustomCode(1)), "outer", WithHTTPCode(503)), 503, true},
+		{"nil", nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetHTTPCode(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GetHTTPCode() = (%d, %t), want (%d, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGetCustomCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   int
+		wantOK bool
+	}{
+		{"unset", New("unset", WithHTTPCode(500)), 0, false},
+		{"explicit zero", New("zero", WithCustomCode(0)), 0, false},
+		{"set", New("set", WithCustomCode(1001)), 1001, true},
+		{"overridden by wrap", Wrap(New("inner", WithCustomCode(1001)), "outer", WithCustomCode(2002)), 2002, true},
+		{"carried by wrap", Wrap(New("inner", WithCustomCode(1001)), "outer"), 1001, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetCustomCode(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GetCustomCode() = (%d, %t), want (%d, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWrapCarriesForwardAttributes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "alice")
+	inner := New("not found", WithHTTPCode(404), WithCustomCode(1001), WithContext(ctx))
+
+	err := Wrap(inner, "lookup failed")
+
+	if got, ok := GetHTTPCode(err); got != 404 || !ok {
+		t.Errorf("GetHTTPCode() = (%d, %t), want (404, true)", got, ok)
+	}
+
+	outer, _ := err.(*CustomError)
+	if outer == nil || outer.HTTPCode != 404 || outer.CustomCode != 1001 || outer.CTX != ctx {
+		t.Errorf("outer layer = %+v, want the inner codes and context", outer)
+	}
+
+	overridden := Wrap(inner, "lookup failed", WithHTTPCode(500))
+	if got, _ := GetHTTPCode(overridden); got != 500 {
+		t.Errorf("GetHTTPCode() with an override = %d, want 500", got)
+	}
+}
+
+type ctxKey string
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"plain error", errSentinel, "sentinel"},
+		{"single", New("not found", WithHTTPCode(404)), "not found"},
+		{"wrapped", Wrap(New("db down", WithHTTPCode(500)), "lookup failed"), "lookup failed"},
+		{"nil", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.err); got != tt.want {
+				t.Errorf("Message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("request"), "r-1")
+
+	tests := []struct {
+		name   string
+		err    error
+		want   context.Context
+		wantOK bool
+	}{
+		{"no context", New("boom", WithHTTPCode(500)), context.Background(), false},
+		{"plain error", errSentinel, context.Background(), false},
+		{"set", New("boom", WithContext(ctx)), ctx, true},
+		{"wrapped", Wrap(New("boom", WithContext(ctx)), "outer", WithHTTPCode(500)), ctx, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Context(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Context() = (%v, %t), want (%v, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsByCustomCode(t *testing.T) {
+	errNotFound := NewCustom("not found")
+	errNotFound.CustomCode = 1001
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"same code", New("user not found", WithCustomCode(1001)), true},
+		{"wrapped", Wrap(New("user not found", WithCustomCode(1001)), "lookup failed"), true},
+		{"other code", New("conflict", WithCustomCode(1002)), false},
+		{"no code", New("boom", WithHTTPCode(404)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(tt.err, errNotFound); got != tt.want {
+				t.Errorf("Is() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+
+	if Is(New("boom", WithHTTPCode(500)), NewCustom("no code")) {
+		t.Error("Is() matched a target without any code")
+	}
+}
+
+func TestErrorWithoutBase(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"plain New", New("boom"), "boom"},
+		{"New with properties", New("boom", WithHTTPCode(500)), "boom"},
+		{"NewCustom", NewCustom("boom"), "boom"},
+		{"wrapped", Wrap(stderrors.New("base"), "boom", WithHTTPCode(500)), "base: boom"},
+		{"base", New("boom", WithBase(stderrors.New("base"))), "base: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	const code = "orders.payment_declined"
+
+	err := Wrap(New("declined", WithCode(code), WithHTTPCode(402)), "checkout failed")
+	if got, ok := Code(err); got != code || !ok {
+		t.Errorf("Code() = (%q, %t), want (%q, true)", got, ok, code)
+	}
+
+	if got, ok := Code(New("boom", WithHTTPCode(500))); got != "" || ok {
+		t.Errorf("Code() without a code = (%q, %t), want (\"\", false)", got, ok)
+	}
+
+	errDeclined := NewCustom("payment declined")
+	errDeclined.Code = code
+	if !Is(err, errDeclined) {
+		t.Error("Is() by code = false, want true")
+	}
+	if Is(New("boom", WithCode("orders.other")), errDeclined) {
+		t.Error("Is() with a different code = true, want false")
+	}
+}
+
+func TestCloneIsolatesFields(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "alice")
+	original := NewCustom("boom")
+	original.base = errSentinel
+	original.CTX = ctx
+	original.Fields = map[string]any{"user_id": 1}
+
+	clone := original.Clone()
+	clone.Fields["user_id"] = 2
+	clone.Fields["order_id"] = 7
+
+	if len(original.Fields) != 1 || original.Fields["user_id"] != 1 {
+		t.Errorf("original Fields = %v, want map[user_id:1]", original.Fields)
+	}
+	if clone.base != original.base || clone.CTX != original.CTX {
+		t.Error("Clone() did not share the base error and the context")
+	}
+
+	if (*CustomError)(nil).Clone() != nil {
+		t.Error("Clone() of a nil CustomError is not nil")
+	}
+}
+
+func TestAsCustom(t *testing.T) {
+	direct := New("direct", WithHTTPCode(404))
+
+	tests := []struct {
+		name   string
+		err    error
+		want   error
+		wantOK bool
+	}{
+		{"plain error", errSentinel, nil, false},
+		{"nil", nil, nil, false},
+		{"direct", direct, direct, true},
+		{"wrapped by a plain error", fmt.Errorf("outer: %w", direct), direct, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AsCustom(tt.err)
+			if ok != tt.wantOK || (tt.wantOK && got != tt.want) || (!tt.wantOK && got != nil) {
+				t.Errorf("AsCustom() = (%v, %t), want (%v, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestHasCodes(t *testing.T) {
+	inner := New("inner", WithHTTPCode(404), WithCustomCode(1001))
+	err := Wrap(inner, "outer", WithHTTPCode(500), WithCustomCode(2002))
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"http outer layer", HasHTTPCode(err, 500), true},
+		{"http inner layer only", HasHTTPCode(err, 404), true},
+		{"http no match", HasHTTPCode(err, 503), false},
+		{"http zero", HasHTTPCode(New("boom", WithCustomCode(1)), 0), false},
+		{"custom outer layer", HasCustomCode(err, 2002), true},
+		{"custom inner layer only", HasCustomCode(err, 1001), true},
+		{"custom no match", HasCustomCode(err, 3003), false},
+		{"custom zero", HasCustomCode(New("boom", WithHTTPCode(500)), 0), false},
+		{"plain error", HasHTTPCode(errSentinel, 500), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %t, want %t", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewfWrapf(t *testing.T) {
+	if got, want := Newf("user %d not found", 42).Error(), New(fmt.Sprintf("user %d not found", 42)).Error(); got != want {
+		t.Errorf("Newf() = %q, want %q", got, want)
+	}
+
+	err := Wrapf(New("inner", WithHTTPCode(404)), "user %q", "alice")
+	if got, want := err.Error(), "inner: user \"alice\""; got != want {
+		t.Errorf("Wrapf() = %q, want %q", got, want)
+	}
+	if code, _ := GetHTTPCode(err); code != 404 {
+		t.Errorf("GetHTTPCode() = %d, want 404", code)
+	}
+	if Wrapf(nil, "user %d", 1) != nil {
+		t.Error("Wrapf(nil) is not nil")
+	}
+}
+
+func TestNewfWrapfReturnCustomError(t *testing.T) {
+	newErr := Newf("user %d not found", 42)
+	if _, ok := newErr.(*CustomError); !ok || !HasStack(newErr) {
+		t.Errorf("Newf() = %T with stack %t, want a *CustomError with a stack", newErr, HasStack(newErr))
+	}
+
+	wrapped := Wrapf(io.EOF, "reading %s", "config.yaml")
+	if _, ok := wrapped.(*CustomError); !ok || !HasStack(wrapped) {
+		t.Errorf("Wrapf() = %T with stack %t, want a *CustomError with a stack", wrapped, HasStack(wrapped))
+	}
+	if got, want := wrapped.Error(), errors.Wrapf(io.EOF, "reading %s", "config.yaml").Error(); got != want {
+		t.Errorf("Wrapf() of a plain error = %q, want %q like errors.Wrapf", got, want)
+	}
+	if !Is(wrapped, io.EOF) || Message(wrapped) != "reading config.yaml" {
+		t.Errorf("Wrapf() = %v, want io.EOF wrapped with the formatted message", wrapped)
+	}
+}
+
+func TestWithHTTPCodeAndText(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"empty message", New("", WithHTTPCodeAndText(404)), "Not Found"},
+		{"existing message", New("user missing", WithHTTPCodeAndText(404)), "user missing"},
+		{"plain WithHTTPCode", New("", WithHTTPCode(404)), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.err); got != tt.want {
+				t.Errorf("Message() = %q, want %q", got, tt.want)
+			}
+			if code, _ := GetHTTPCode(tt.err); code != 404 {
+				t.Errorf("GetHTTPCode() = %d, want 404", code)
+			}
+		})
+	}
+}
+
+func TestWithBase(t *testing.T) {
+	low := fmt.Errorf("query: %w", errSentinel)
+	err := New("high-level", WithHTTPCode(500), WithBase(low))
+
+	customErr := err.(*CustomError)
+	if customErr.Unwrap() != low || customErr.Cause() != low {
+		t.Errorf("Unwrap() = %v, Cause() = %v, want the base", customErr.Unwrap(), customErr.Cause())
+	}
+	if !Is(err, errSentinel) {
+		t.Error("Is(err, errSentinel) = false, want true")
+	}
+	if got, want := err.Error(), "query: sentinel: high-level"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	replaced := WithBase(io.EOF)(err)
+	if Is(replaced, errSentinel) || !Is(replaced, io.EOF) {
+		t.Error("WithBase did not replace the existing base")
+	}
+}
+
+func TestNilReceiver(t *testing.T) {
+	var customErr *CustomError
+
+	if got := customErr.Error(); got != "" {
+		t.Errorf("Error() = %q, want an empty string", got)
+	}
+	if got := customErr.Cause(); got != nil {
+		t.Errorf("Cause() = %v, want nil", got)
+	}
+	if got := customErr.Unwrap(); got != nil {
+		t.Errorf("Unwrap() = %v, want nil", got)
+	}
+	if customErr.Is(errSentinel) {
+		t.Error("Is() = true, want false")
+	}
+	if customErr.Temporary() {
+		t.Error("Temporary() = true, want false")
+	}
+	if got := customErr.StackTrace(); got != nil {
+		t.Errorf("StackTrace() = %v, want nil", got)
+	}
+	if got := fmt.Sprintf("%+v", customErr); got != "<nil>" {
+		t.Errorf("%%+v = %q, want <nil>", got)
+	}
+	if got := customErr.LogValue().Group(); len(got) != 0 {
+		t.Errorf("LogValue() = %v, want an empty group", got)
+	}
+}
+
+func TestNilReceiverMarshalJSON(t *testing.T) {
+	var customErr *CustomError
+	var validationErr *ValidationError
+
+	for name, marshaler := range map[string]json.Marshaler{
+		"CustomError":                     customErr,
+		"ValidationError":                 validationErr,
+		"ValidationError without a layer": &ValidationError{},
+	} {
+		data, err := marshaler.MarshalJSON()
+		if err != nil || string(data) != "null" {
+			t.Errorf("%s MarshalJSON() = (%s, %v), want (null, nil)", name, data, err)
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Err *CustomError `json:"err"`
+	}{})
+	if err != nil || string(data) != `{"err":null}` {
+		t.Errorf("json.Marshal() = (%s, %v), want {\"err\":null}", data, err)
+	}
+}
+
+func TestNewCustom(t *testing.T) {
+	customErr := NewCustom("not found")
+	customErr.HTTPCode = 404
+
+	var err error = customErr
+	if code, ok := GetHTTPCode(err); code != 404 || !ok {
+		t.Errorf("GetHTTPCode() = (%d, %t), want (404, true)", code, ok)
+	}
+
+	wrapped := Wrap(err, "lookup failed")
+	if !Is(wrapped, customErr) {
+		t.Error("Is(wrapped, customErr) = false, want true")
+	}
+
+	var target *CustomError
+	if !As(fmt.Errorf("outer: %w", customErr), &target) || target != customErr {
+		t.Error("As() did not find the CustomError created via NewCustom")
+	}
+}
+
+var errNotFoundSentinel = New("not found", WithHTTPCode(404))
+
+func TestSentinelIdentity(t *testing.T) {
+	err := Wrap(Wrap(errNotFoundSentinel, "user lookup failed"), "request failed", WithHTTPCode(500))
+
+	if !Is(err, errNotFoundSentinel) {
+		t.Error("Is(err, errNotFoundSentinel) = false, want true")
+	}
+	if !stderrors.Is(err, errNotFoundSentinel) {
+		t.Error("errors.Is(err, errNotFoundSentinel) = false, want true")
+	}
+	if Is(New("not found", WithHTTPCode(404)), errNotFoundSentinel) {
+		t.Error("Is() matched a different error without codes")
+	}
+	if code, _ := GetHTTPCode(errNotFoundSentinel); code != 404 {
+		t.Errorf("wrapping modified the sentinel: HTTP code %d, want 404", code)
+	}
+}
+
+func TestWrapw(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		args        []any
+		wantMessage string
+	}{
+		{"leading verb", "%w: loading %s", []any{"config.yaml"}, "loading config.yaml"},
+		{"trailing verb", "loading %s: %w", []any{"config.yaml"}, "loading config.yaml"},
+		{"escaped percent", "%w: 100%% of %d", []any{3}, "100% of 3"},
+		{"no verb", "loading %s", []any{"config.yaml"}, "loading config.yaml"},
+		{"parenthesized verb", "loading %s (%w)", []any{"x"}, "loading x"},
+		{"bracketed verb", "loading %s [%w] again", []any{"x"}, "loading x again"},
+		{"quoted verb", "loading %s: '%w'", []any{"x"}, "loading x"},
+		{"middle verb", "loading %s: %w: retrying", []any{"x"}, "loading x: retrying"},
+		{"verb between words", "open %w failed", nil, "open failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Wrapw(errSentinel, tt.format, tt.args...)
+
+			customErr, ok := err.(*CustomError)
+			if !ok {
+				t.Fatalf("Wrapw() is %T, want *CustomError", err)
+			}
+			if customErr.Unwrap() != errSentinel {
+				t.Errorf("Unwrap() = %v, want the wrapped error", customErr.Unwrap())
+			}
+			if customErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", customErr.Message, tt.wantMessage)
+			}
+			if got, want := err.Error(), "sentinel: "+tt.wantMessage; got != want {
+				t.Errorf("Error() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	if Wrapw(nil, "%w: x") != nil {
+		t.Error("Wrapw(nil) is not nil")
+	}
+}
+
+func TestWrapwCarriesForwardCodes(t *testing.T) {
+	err := Wrapw(New("not found", WithHTTPCode(404)), "%w: user %d", 7)
+
+	if code, _ := GetHTTPCode(err); code != 404 {
+		t.Errorf("GetHTTPCode() = %d, want 404", code)
+	}
+}
+
+func TestMessageSeparator(t *testing.T) {
+	err := Wrap(New("inner", WithHTTPCode(500)), "outer")
+
+	if got := err.Error(); got != "inner: outer" {
+		t.Errorf("Error() with the default separator = %q, want %q", got, "inner: outer")
+	}
+
+	MessageSeparator = " -> "
+	defer func() { MessageSeparator = ": " }()
+
+	if got := err.Error(); got != "inner -> outer" {
+		t.Errorf("Error() with a custom separator = %q, want %q", got, "inner -> outer")
+	}
+}
+
+func TestIsCode(t *testing.T) {
+	inner := New("declined", WithCode("orders.payment_declined"))
+	err := Wrap(inner, "checkout failed", WithCode("orders.checkout_failed"))
+
+	tests := []struct {
+		name string
+		err  error
+		code string
+		want bool
+	}{
+		{"outer layer", err, "orders.checkout_failed", true},
+		{"inner layer", err, "orders.payment_declined", true},
+		{"other code", err, "orders.other", false},
+		{"empty code", err, "", false},
+		{"empty code on an error without one", New("boom", WithHTTPCode(500)), "", false},
+		{"plain error", errSentinel, "orders.checkout_failed", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCode(tt.err, tt.code); got != tt.want {
+				t.Errorf("IsCode(%q) = %t, want %t", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithReplaceMessage(t *testing.T) {
+	err := Wrap(errSentinel, "db: row 42 locked", WithHTTPCode(503), WithFields(map[string]any{"table": "orders"}))
+	replaced := WithReplaceMessage("service temporarily unavailable")(err)
+
+	if replaced != err {
+		t.Error("WithReplaceMessage() returned a new error, want the same error")
+	}
+	if got := Message(replaced); got != "service temporarily unavailable" {
+		t.Errorf("Message() = %q, want the replaced message", got)
+	}
+	if code, _ := GetHTTPCode(replaced); code != 503 {
+		t.Errorf("GetHTTPCode() = %d, want 503", code)
+	}
+	if !Is(replaced, errSentinel) || stderrors.Unwrap(replaced) != errSentinel {
+		t.Error("the base error was not kept")
+	}
+	if Fields(replaced)["table"] != "orders" {
+		t.Errorf("Fields() = %v, want the table field", Fields(replaced))
+	}
+
+	if got := WithReplaceMessage("rephrased")(errSentinel); got.Error() != "rephrased" {
+		t.Errorf("WithReplaceMessage() on a plain error = %q, want %q", got.Error(), "rephrased")
+	}
+}
+
+// myErr is a user-defined error type found by AsType.
+type myErr struct{ op string }
+
+func (e *myErr) Error() string { return e.op + " failed" }
+
+func TestAsType(t *testing.T) {
+	inner := New("not found", WithHTTPCode(404))
+	err := fmt.Errorf("handler: %w", Wrap(inner, "lookup failed", WithCustomCode(1001)))
+
+	customErr, ok := AsType[*CustomError](err)
+	if !ok || customErr.Message != "lookup failed" {
+		t.Errorf("AsType[*CustomError]() = (%v, %t), want the outer CustomError", customErr, ok)
+	}
+
+	mine := &myErr{op: "query"}
+	got, ok := AsType[*myErr](Wrap(fmt.Errorf("db: %w", mine), "lookup failed", WithHTTPCode(500)))
+	if !ok || got != mine {
+		t.Errorf("AsType[*myErr]() = (%v, %t), want (%v, true)", got, ok, mine)
+	}
+
+	if got, ok := AsType[*myErr](err); got != nil || ok {
+		t.Errorf("AsType[*myErr]() without a match = (%v, %t), want (nil, false)", got, ok)
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	inner := Wrap(errSentinel, "query failed", WithHTTPCode(503))
+	err := Wrap(inner, "lookup failed")
+
+	enriched := Enrich(err, WithFields(map[string]any{"table": "orders"}), WithCustomCode(1001))
+	if enriched != err {
+		t.Error("Enrich() returned a new error, want the same error")
+	}
+	if got := len(AllCustom(enriched)); got != 2 {
+		t.Errorf("Enrich() left %d CustomErrors in the chain, want 2", got)
+	}
+	if Fields(enriched)["table"] != "orders" {
+		t.Errorf("Fields() = %v, want the table field", Fields(enriched))
+	}
+	if code, _ := GetCustomCode(enriched); code != 1001 {
+		t.Errorf("GetCustomCode() = %d, want 1001", code)
+	}
+	if !Is(enriched, errSentinel) || !Is(enriched, inner) {
+		t.Error("Is() of the wrapped errors = false, want true")
+	}
+
+	if Enrich(nil, WithHTTPCode(500)) != nil {
+		t.Error("Enrich(nil) is not nil")
+	}
+}
+
+func TestEnrichPlainError(t *testing.T) {
+	err := Enrich(io.EOF, WithHTTPCode(500), WithFields(map[string]any{"file": "a.txt"}))
+
+	if !Is(err, io.EOF) || !stderrors.Is(err, io.EOF) {
+		t.Error("Is(io.EOF) = false, want true")
+	}
+	if err.Error() != io.EOF.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), io.EOF.Error())
+	}
+	if got := fmt.Sprintf("%+v", err); got != "EOF" {
+		t.Errorf("%%+v = %q, want %q", got, "EOF")
+	}
+	if code, _ := GetHTTPCode(err); code != 500 {
+		t.Errorf("GetHTTPCode() = %d, want 500", code)
+	}
+	if Fields(err)["file"] != "a.txt" {
+		t.Errorf("Fields() = %v, want the file field", Fields(err))
+	}
+	if got := len(AllCustom(err)); got != 1 {
+		t.Errorf("Enrich() of a plain error created %d CustomErrors, want 1", got)
+	}
+}
@@ -0,0 +1,56 @@
+// Package errxcli maps errx errors to process exit codes for command-line programs.
+// It is kept separate from the errx package so that os.Exit stays out of the core.
+package errxcli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hamidghavidel/errx"
+)
+
+// defaultExitCode is the exit code of errors that have none set.
+const defaultExitCode = 1
+
+var (
+	// exit terminates the process; it is replaced in tests.
+	exit = os.Exit
+
+	// stderr is where FatalIfErr prints errors; it is replaced in tests.
+	stderr io.Writer = os.Stderr
+)
+
+// exitCodeKey is the key under which WithExitCode attaches the exit code of an error via errx.WithValue.
+type exitCodeKey struct{}
+
+// WithExitCode returns a Property that sets the process exit code of an error.
+// The code is attached via errx.WithValue, so it is not part of the error's fields or serialized
+// form, and is carried by every error wrapping it. When applied by errx.Wrap, it sets the code on
+// the new layer, leaving the wrapped error unchanged.
+// If the error is a CustomError, it updates the existing error.
+// Otherwise, it creates a new CustomError with the specified code.
+func WithExitCode(code int) errx.Property {
+	return errx.WithValue(exitCodeKey{}, code)
+}
+
+// ExitCode returns the exit code set via WithExitCode on the first CustomError in err's chain that has one.
+// As an error never maps to a successful exit, it returns 1 when no code or a code of 0 is set.
+func ExitCode(err error) int {
+	if code, ok := errx.Value(err, exitCodeKey{}); ok && code.(int) != 0 {
+		return code.(int)
+	}
+
+	return defaultExitCode
+}
+
+// FatalIfErr prints the given error to standard error and exits the process with its exit code.
+// It does nothing for a nil error.
+func FatalIfErr(err error) {
+	if err == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintln(stderr, err)
+	exit(ExitCode(err))
+}
@@ -0,0 +1,96 @@
+package errxcli
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/hamidghavidel/errx"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"default", errx.New("boom", errx.WithHTTPCode(500)), 1},
+		{"plain error", stderrors.New("boom"), 1},
+		{"set", errx.New("usage", WithExitCode(2)), 2},
+		{"zero", errx.New("boom", WithExitCode(0)), 1},
+		{"through a wrap", errx.Wrap(errx.New("usage", WithExitCode(64)), "parse flags"), 64},
+		{"overridden by a wrap", errx.Wrap(errx.New("usage", WithExitCode(64)), "config", WithExitCode(78)), 78},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithExitCodeLeavesSentinelUnchanged(t *testing.T) {
+	sentinel := errx.New("usage", WithExitCode(64))
+
+	err := errx.Wrap(sentinel, "parse flags", WithExitCode(2))
+
+	if got := ExitCode(sentinel); got != 64 {
+		t.Errorf("ExitCode(sentinel) = %d, want 64", got)
+	}
+	if got := ExitCode(err); got != 2 {
+		t.Errorf("ExitCode(err) = %d, want 2", got)
+	}
+	if _, ok := err.(*errx.CustomError); !ok {
+		t.Errorf("err is %T, want *errx.CustomError", err)
+	}
+}
+
+func TestWithExitCodeNotSerialized(t *testing.T) {
+	err := errx.New("usage", WithExitCode(64), errx.WithFields(map[string]any{"exit_code": "user"}))
+
+	if got := ExitCode(err); got != 64 {
+		t.Errorf("ExitCode() = %d, want 64", got)
+	}
+	if got := errx.Fields(err)["exit_code"]; got != "user" {
+		t.Errorf("Fields()[\"exit_code\"] = %v, want the user field", got)
+	}
+
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("json.Marshal() error = %v", jsonErr)
+	}
+	if strings.Contains(string(data), "64") {
+		t.Errorf("json.Marshal() = %s, want no exit code", data)
+	}
+}
+
+func TestFatalIfErr(t *testing.T) {
+	var buf bytes.Buffer
+	code := -1
+
+	origExit, origStderr := exit, stderr
+	exit = func(c int) { code = c }
+	stderr = &buf
+	defer func() {
+		exit, stderr = origExit, origStderr
+	}()
+
+	FatalIfErr(errx.New("config missing", WithExitCode(78)))
+	if code != 78 {
+		t.Errorf("exit code = %d, want 78", code)
+	}
+	if got := buf.String(); got != "config missing\n" {
+		t.Errorf("stderr = %q, want %q", got, "config missing\n")
+	}
+
+	code = -1
+	buf.Reset()
+	FatalIfErr(nil)
+	if code != -1 || buf.Len() != 0 {
+		t.Errorf("FatalIfErr(nil) exited with %d and printed %q, want nothing", code, buf.String())
+	}
+}
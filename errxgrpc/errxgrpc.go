@@ -0,0 +1,92 @@
+// Package errxgrpc converts errx errors into gRPC statuses.
+// It is kept separate from the errx package so that users who do not use gRPC
+// are not forced to depend on it.
+package errxgrpc
+
+import (
+	"net/http"
+
+	"github.com/hamidghavidel/errx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpToGRPC maps common HTTP codes to their gRPC equivalents.
+var httpToGRPC = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	499:                            codes.Canceled,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// codeKey is the key under which WithGRPCCode attaches the gRPC code of an error via errx.WithValue.
+type codeKey struct{}
+
+// codeError holds the gRPC code attached to an error.
+// It is attached as an error value, so that status.FromError finds its GRPCStatus method in the chain.
+type codeError struct {
+	code codes.Code
+}
+
+// Error returns the name of the gRPC code.
+func (e *codeError) Error() string {
+	return e.code.String()
+}
+
+// GRPCStatus returns a status with the gRPC code, which allows status.FromError to convert
+// the error holding it. status.FromError replaces the message of the status with the message
+// of the error it converts.
+func (e *codeError) GRPCStatus() *status.Status {
+	return status.New(e.code, e.code.String())
+}
+
+// WithGRPCCode returns a Property that sets the gRPC code of an error, which status.FromError
+// and ToGRPCStatus then report for it and for every error wrapping it.
+// The code is attached via errx.WithValue, so it is not part of the error's fields or serialized
+// form. When applied by errx.Wrap, it sets the code on the new layer, leaving the wrapped error unchanged.
+// If the error is a CustomError, it updates the existing error.
+// Otherwise, it creates a new CustomError with the specified code.
+func WithGRPCCode(code codes.Code) errx.Property {
+	return errx.WithValue(codeKey{}, &codeError{code: code})
+}
+
+// GRPCCode returns the gRPC code set via WithGRPCCode on the first CustomError in err's chain that has one.
+// The boolean result reports whether such a code was found.
+func GRPCCode(err error) (codes.Code, bool) {
+	value, ok := errx.Value(err, codeKey{})
+	if !ok {
+		return codes.Unknown, false
+	}
+
+	return value.(*codeError).code, true
+}
+
+// ToGRPCStatus converts the given error into a gRPC status carrying the error's message.
+// It uses the gRPC code set via WithGRPCCode if present. Otherwise, it maps the error's HTTP code
+// to the equivalent gRPC code, falling back to codes.Unknown.
+// It returns nil for a nil error.
+func ToGRPCStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	code, ok := GRPCCode(err)
+	if !ok {
+		code = codes.Unknown
+		if httpCode, ok := errx.GetHTTPCode(err); ok {
+			if mapped, ok := httpToGRPC[httpCode]; ok {
+				code = mapped
+			}
+		}
+	}
+
+	return status.New(code, errx.Message(err))
+}
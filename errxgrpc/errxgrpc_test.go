@@ -0,0 +1,126 @@
+package errxgrpc
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hamidghavidel/errx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToGRPCStatusExplicitCode(t *testing.T) {
+	err := errx.Wrap(errx.New("not found", errx.WithHTTPCode(http.StatusNotFound)), "lookup failed",
+		WithGRPCCode(codes.Unavailable))
+
+	st := ToGRPCStatus(err)
+	if st.Code() != codes.Unavailable {
+		t.Errorf("Code() = %v, want %v", st.Code(), codes.Unavailable)
+	}
+	if st.Message() != "lookup failed" {
+		t.Errorf("Message() = %q, want %q", st.Message(), "lookup failed")
+	}
+}
+
+func TestToGRPCStatusHTTPFallback(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", errx.New("missing", errx.WithHTTPCode(http.StatusNotFound)), codes.NotFound},
+		{"bad request", errx.New("invalid", errx.WithHTTPCode(http.StatusBadRequest)), codes.InvalidArgument},
+		{"unavailable", errx.New("down", errx.WithHTTPCode(http.StatusServiceUnavailable)), codes.Unavailable},
+		{"unmapped", errx.New("teapot", errx.WithHTTPCode(http.StatusTeapot)), codes.Unknown},
+		{"plain error", stderrors.New("plain"), codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToGRPCStatus(tt.err).Code(); got != tt.want {
+				t.Errorf("Code() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if st := ToGRPCStatus(nil); st != nil {
+		t.Errorf("ToGRPCStatus(nil) = %v, want nil", st)
+	}
+}
+
+func TestWithGRPCCodeLeavesSentinelUnchanged(t *testing.T) {
+	sentinel := errx.New("not found", WithGRPCCode(codes.NotFound))
+
+	err := errx.Wrap(sentinel, "lookup failed", WithGRPCCode(codes.Internal))
+
+	if code, _ := GRPCCode(sentinel); code != codes.NotFound {
+		t.Errorf("GRPCCode(sentinel) = %v, want %v", code, codes.NotFound)
+	}
+	if code, _ := GRPCCode(err); code != codes.Internal {
+		t.Errorf("GRPCCode(err) = %v, want %v", code, codes.Internal)
+	}
+	if !errx.Is(err, sentinel) {
+		t.Error("Is(err, sentinel) = false, want true")
+	}
+	if _, ok := err.(*errx.CustomError); !ok {
+		t.Errorf("err is %T, want *errx.CustomError", err)
+	}
+}
+
+func TestGRPCCodeUnset(t *testing.T) {
+	if code, ok := GRPCCode(errx.New("boom", errx.WithHTTPCode(500))); code != codes.Unknown || ok {
+		t.Errorf("GRPCCode() = (%v, %t), want (%v, false)", code, ok, codes.Unknown)
+	}
+}
+
+func TestStatusFromError(t *testing.T) {
+	sentinel := errx.New("not found", WithGRPCCode(codes.NotFound))
+
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"direct", sentinel, codes.NotFound},
+		{"wrapped by errx", errx.Wrap(sentinel, "lookup failed", errx.WithHTTPCode(http.StatusNotFound)), codes.NotFound},
+		{"wrapped by fmt", fmt.Errorf("handler: %w", sentinel), codes.NotFound},
+		{"outer wins", errx.Wrap(sentinel, "lookup failed", WithGRPCCode(codes.Internal)), codes.Internal},
+		{"unset", errx.New("boom", errx.WithHTTPCode(http.StatusInternalServerError)), codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(tt.err)
+			if st.Code() != tt.want || ok != (tt.want != codes.Unknown) {
+				t.Errorf("status.FromError() = (%v, %t), want (%v, %t)", st.Code(), ok, tt.want, tt.want != codes.Unknown)
+			}
+			if st.Message() != tt.err.Error() {
+				t.Errorf("Message() = %q, want %q", st.Message(), tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestWithGRPCCodeNotSerialized(t *testing.T) {
+	err := errx.New("not found", errx.WithFields(map[string]any{"grpc_code": "user value"}), WithGRPCCode(codes.NotFound))
+
+	if got := errx.Fields(err); len(got) != 1 || got["grpc_code"] != "user value" {
+		t.Errorf("Fields() = %v, want the user field only", got)
+	}
+
+	data, _ := json.Marshal(err)
+	if want := `{"message":"not found","fields":{"grpc_code":"user value"}}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+	if strings.Contains(errx.Display(err), "NotFound") {
+		t.Errorf("Display() = %q, want no gRPC code", errx.Display(err))
+	}
+
+	if code, _ := GRPCCode(err); code != codes.NotFound {
+		t.Errorf("GRPCCode() = %v, want %v", code, codes.NotFound)
+	}
+}
@@ -0,0 +1,171 @@
+// Package errxhttp writes errx errors as HTTP responses.
+// It is kept separate from the errx package so that net/http concerns stay out of the core.
+package errxhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hamidghavidel/errx"
+)
+
+// IncludeCause controls whether WriteHTTP includes the message of the base error in the response body.
+// It is false by default so that internal details are not leaked to clients.
+var IncludeCause bool
+
+// WriteHTTP writes the given error to w as a JSON response.
+// The status is resolved via errx.ResolveHTTPCode: the HTTP code of the error, else the code
+// mapped to its category in errx.CategoryHTTPCodes, else 500.
+// A CustomError is encoded via its MarshalJSON, with the cause removed unless IncludeCause is set
+// and without the fields marked via errx.WithSensitiveFields, and the field errors of a ValidationError are included under an "errors" key.
+// Any other error is encoded with the standard status text as its message.
+// The headers set on the error via errx.WithHTTPHeaders are written before the body.
+// It writes nothing for a nil error.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	if err == nil {
+		return
+	}
+
+	status := errx.ResolveHTTPCode(err)
+
+	body, marshalErr := responseBody(err, status)
+	if marshalErr != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	for key, values := range errx.HTTPHeaders(err) {
+		w.Header()[key] = values
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// responseBody returns the JSON body written by WriteHTTP for the given error.
+// If err implements json.Marshaler itself, as a ValidationError does, its own encoding is used,
+// keeping the order of its keys. The field errors of a ValidationError wrapped within err are
+// added last, under the "errors" key.
+func responseBody(err error, status int) ([]byte, error) {
+	var customErr *errx.CustomError
+	if !errx.As(err, &customErr) {
+		payload := map[string]string{"message": http.StatusText(status)}
+		if IncludeCause {
+			payload["cause"] = err.Error()
+		}
+
+		return json.Marshal(payload)
+	}
+
+	var marshaler json.Marshaler = customErr
+	if errMarshaler, ok := err.(json.Marshaler); ok {
+		marshaler = errMarshaler
+	}
+
+	body, marshalErr := json.Marshal(marshaler)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	members, decodeErr := decodeObject(body)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	result := members[:0]
+	hasErrors := false
+	for _, m := range members {
+		switch m.key {
+		case "cause":
+			if !IncludeCause {
+				continue
+			}
+		case "fields":
+			fields := errx.SafeFields(err)
+			if len(fields) == 0 {
+				continue
+			}
+
+			encoded, marshalErr := json.Marshal(fields)
+			if marshalErr != nil {
+				return nil, marshalErr
+			}
+
+			m.value = encoded
+		case "errors":
+			hasErrors = true
+		}
+
+		result = append(result, m)
+	}
+
+	if !hasErrors {
+		if fieldErrors, ok := errx.FieldErrors(err); ok && len(fieldErrors) > 0 {
+			encoded, marshalErr := json.Marshal(fieldErrors)
+			if marshalErr != nil {
+				return nil, marshalErr
+			}
+
+			result = append(result, member{key: "errors", value: encoded})
+		}
+	}
+
+	return encodeObject(result), nil
+}
+
+// member is a member of a JSON object, holding its key and its encoded value.
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+// decodeObject returns the members of the given JSON object in the order they appear, so that
+// the object can be modified and encoded again without changing the order of its keys.
+func decodeObject(data []byte) ([]member, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('{') {
+		return nil, fmt.Errorf("errxhttp: encoded error is not a JSON object")
+	}
+
+	var members []member
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		members = append(members, member{key: token.(string), value: value})
+	}
+
+	return members, nil
+}
+
+// encodeObject encodes the given members as a JSON object, in their order.
+func encodeObject(members []member) []byte {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(m.key)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(m.value)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes()
+}
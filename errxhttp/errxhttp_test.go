@@ -0,0 +1,176 @@
+package errxhttp
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hamidghavidel/errx"
+)
+
+func TestWriteHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "custom error",
+			err:        errx.Wrap(stderrors.New("no rows"), "user not found", errx.WithHTTPCode(http.StatusNotFound), errx.WithCustomCode(1001)),
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"user not found","http_code":404,"custom_code":1001}`,
+		},
+		{
+			name:       "custom error without code",
+			err:        errx.NewCustom("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"boom"}`,
+		},
+		{
+			name:       "plain error",
+			err:        stderrors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"Internal Server Error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteHTTP(rec, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %s, want %s", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestWriteHTTPIncludeCause(t *testing.T) {
+	IncludeCause = true
+	defer func() { IncludeCause = false }()
+
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errx.Wrap(stderrors.New("no rows"), "user not found", errx.WithHTTPCode(http.StatusNotFound)))
+
+	if want := `{"message":"user not found","http_code":404,"cause":"no rows"}`; rec.Body.String() != want {
+		t.Errorf("body = %s, want %s", rec.Body.String(), want)
+	}
+}
+
+func TestWriteHTTPNil(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, nil)
+
+	if rec.Body.Len() != 0 || len(rec.Header()) != 0 {
+		t.Errorf("WriteHTTP(nil) wrote %d bytes and headers %v, want nothing", rec.Body.Len(), rec.Header())
+	}
+}
+
+func TestWriteHTTPValidationError(t *testing.T) {
+	fields := map[string]string{"email": "is invalid", "name": "is required"}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "validation error",
+			err:  errx.NewValidation(fields),
+			want: `{"message":"validation failed","http_code":422,"errors":{"email":"is invalid","name":"is required"}}`,
+		},
+		{
+			name: "wrapped validation error",
+			err:  errx.Wrap(errx.NewValidation(fields), "signup failed"),
+			want: `{"message":"signup failed","http_code":422,"errors":{"email":"is invalid","name":"is required"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteHTTP(rec, tt.err)
+
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+			}
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHTTPCategoryStatus(t *testing.T) {
+	errx.CategoryHTTPCodes["validation"] = http.StatusBadRequest
+	defer delete(errx.CategoryHTTPCodes, "validation")
+
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errx.New("invalid email", errx.WithCategory("validation")))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = httptest.NewRecorder()
+	WriteHTTP(rec, errx.New("invalid email", errx.WithCategory("validation"), errx.WithHTTPCode(http.StatusConflict)))
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status with an explicit code = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestWriteHTTPHeaders(t *testing.T) {
+	err := errx.Wrap(
+		errx.New("maintenance", errx.WithHTTPHeaders(http.Header{"retry-after": {"120"}})),
+		"service unavailable",
+		errx.WithHTTPCode(http.StatusServiceUnavailable),
+	)
+
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, err)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "120" {
+		t.Errorf("Retry-After = %q, want %q", got, "120")
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+}
+
+func TestWriteHTTPSensitiveFields(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errx.New("login failed",
+		errx.WithHTTPCode(http.StatusUnauthorized),
+		errx.WithFields(map[string]any{"password": "hunter2", "user": "alice"}),
+		errx.WithSensitiveFields("password")))
+
+	if body := rec.Body.String(); strings.Contains(body, "hunter2") || strings.Contains(body, "password") {
+		t.Errorf("body = %s, want no sensitive field", body)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `"fields":{"user":"alice"}`) {
+		t.Errorf("body = %s, want the other fields", body)
+	}
+
+	rec = httptest.NewRecorder()
+	WriteHTTP(rec, errx.New("login failed",
+		errx.WithFields(map[string]any{"password": "hunter2"}),
+		errx.WithSensitiveFields("password")))
+
+	if body := rec.Body.String(); strings.Contains(body, "fields") {
+		t.Errorf("body = %s, want no fields", body)
+	}
+}
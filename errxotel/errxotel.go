@@ -0,0 +1,43 @@
+// Package errxotel records errx errors on OpenTelemetry spans.
+// It is kept separate from the errx package so that users who do not use OpenTelemetry
+// are not forced to depend on it.
+package errxotel
+
+import (
+	"context"
+
+	"github.com/hamidghavidel/errx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordSpanError records the given error on the span carried by ctx and sets the span status to Error.
+// The HTTP code, custom code and category of the error are added as span attributes when present.
+// It does nothing for a nil error.
+func RecordSpanError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, errx.Message(err))
+
+	var attrs []attribute.KeyValue
+	if httpCode, ok := errx.GetHTTPCode(err); ok {
+		attrs = append(attrs, attribute.Int("http_code", httpCode))
+	}
+
+	if customCode, ok := errx.GetCustomCode(err); ok {
+		attrs = append(attrs, attribute.Int("custom_code", customCode))
+	}
+
+	if category, ok := errx.Category(err); ok {
+		attrs = append(attrs, attribute.String("category", category))
+	}
+
+	if len(attrs) > 0 {
+		span.SetAttributes(attrs...)
+	}
+}
@@ -0,0 +1,82 @@
+package errxotel
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/hamidghavidel/errx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpan records err on a new span and returns the span once it has ended.
+func recordSpan(t *testing.T, err error) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, span := provider.Tracer("errxotel").Start(context.Background(), "operation")
+	RecordSpanError(ctx, err)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+
+	return spans[0]
+}
+
+func TestRecordSpanError(t *testing.T) {
+	err := errx.Wrap(stderrors.New("no rows"), "user not found",
+		errx.WithHTTPCode(404), errx.WithCustomCode(1001), errx.WithCategory("database"))
+
+	span := recordSpan(t, err)
+
+	if span.Status().Code != codes.Error || span.Status().Description != "user not found" {
+		t.Errorf("Status() = %+v, want an error status with the message", span.Status())
+	}
+
+	want := map[attribute.Key]attribute.Value{
+		"http_code":   attribute.IntValue(404),
+		"custom_code": attribute.IntValue(1001),
+		"category":    attribute.StringValue("database"),
+	}
+	got := span.Attributes()
+	if len(got) != len(want) {
+		t.Errorf("Attributes() = %v, want %v", got, want)
+	}
+	for _, attr := range got {
+		if want[attr.Key] != attr.Value {
+			t.Errorf("attribute %s = %v, want %v", attr.Key, attr.Value.Emit(), want[attr.Key].Emit())
+		}
+	}
+
+	events := span.Events()
+	if len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("Events() = %v, want a single exception event", events)
+	}
+}
+
+func TestRecordSpanErrorPlainError(t *testing.T) {
+	span := recordSpan(t, stderrors.New("boom"))
+
+	if len(span.Attributes()) != 0 {
+		t.Errorf("Attributes() = %v, want none", span.Attributes())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("Status() = %+v, want an error status", span.Status())
+	}
+}
+
+func TestRecordSpanErrorNil(t *testing.T) {
+	span := recordSpan(t, nil)
+
+	if span.Status().Code != codes.Unset || len(span.Events()) != 0 {
+		t.Errorf("span = %+v, want it untouched", span)
+	}
+}
@@ -0,0 +1,191 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: errxpb/errx.proto
+
+package errxpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Error is the serialized form of an errx error.
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The message of the error, without the message of its cause.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The HTTP code of the error, or 0 if none is set.
+	HttpCode int32 `protobuf:"varint,2,opt,name=http_code,json=httpCode,proto3" json:"http_code,omitempty"`
+	// The custom code of the error, or 0 if none is set.
+	CustomCode int32 `protobuf:"varint,3,opt,name=custom_code,json=customCode,proto3" json:"custom_code,omitempty"`
+	// The namespaced code of the error, or empty if none is set.
+	Code string `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	// The message of the base error, or empty if there is none.
+	Cause string `protobuf:"bytes,5,opt,name=cause,proto3" json:"cause,omitempty"`
+	// The fields collected from the whole chain.
+	Fields *structpb.Struct `protobuf:"bytes,6,opt,name=fields,proto3" json:"fields,omitempty"`
+	// The details collected from the whole chain that are protocol buffer messages.
+	Details       []*anypb.Any `protobuf:"bytes,7,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_errxpb_errx_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_errxpb_errx_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_errxpb_errx_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetHttpCode() int32 {
+	if x != nil {
+		return x.HttpCode
+	}
+	return 0
+}
+
+func (x *Error) GetCustomCode() int32 {
+	if x != nil {
+		return x.CustomCode
+	}
+	return 0
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetCause() string {
+	if x != nil {
+		return x.Cause
+	}
+	return ""
+}
+
+func (x *Error) GetFields() *structpb.Struct {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Error) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+var File_errxpb_errx_proto protoreflect.FileDescriptor
+
+const file_errxpb_errx_proto_rawDesc = "" +
+	"\n" +
+	"\x11errxpb/errx.proto\x12\aerrx.v1\x1a\x19google/protobuf/any.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xea\x01\n" +
+	"\x05Error\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1b\n" +
+	"\thttp_code\x18\x02 \x01(\x05R\bhttpCode\x12\x1f\n" +
+	"\vcustom_code\x18\x03 \x01(\x05R\n" +
+	"customCode\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\tR\x04code\x12\x14\n" +
+	"\x05cause\x18\x05 \x01(\tR\x05cause\x12/\n" +
+	"\x06fields\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06fields\x12.\n" +
+	"\adetails\x18\a \x03(\v2\x14.google.protobuf.AnyR\adetailsB&Z$github.com/hamidghavidel/errx/errxpbb\x06proto3"
+
+var (
+	file_errxpb_errx_proto_rawDescOnce sync.Once
+	file_errxpb_errx_proto_rawDescData []byte
+)
+
+func file_errxpb_errx_proto_rawDescGZIP() []byte {
+	file_errxpb_errx_proto_rawDescOnce.Do(func() {
+		file_errxpb_errx_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_errxpb_errx_proto_rawDesc), len(file_errxpb_errx_proto_rawDesc)))
+	})
+	return file_errxpb_errx_proto_rawDescData
+}
+
+var file_errxpb_errx_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_errxpb_errx_proto_goTypes = []any{
+	(*Error)(nil),           // 0: errx.v1.Error
+	(*structpb.Struct)(nil), // 1: google.protobuf.Struct
+	(*anypb.Any)(nil),       // 2: google.protobuf.Any
+}
+var file_errxpb_errx_proto_depIdxs = []int32{
+	1, // 0: errx.v1.Error.fields:type_name -> google.protobuf.Struct
+	2, // 1: errx.v1.Error.details:type_name -> google.protobuf.Any
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_errxpb_errx_proto_init() }
+func file_errxpb_errx_proto_init() {
+	if File_errxpb_errx_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_errxpb_errx_proto_rawDesc), len(file_errxpb_errx_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_errxpb_errx_proto_goTypes,
+		DependencyIndexes: file_errxpb_errx_proto_depIdxs,
+		MessageInfos:      file_errxpb_errx_proto_msgTypes,
+	}.Build()
+	File_errxpb_errx_proto = out.File
+	file_errxpb_errx_proto_goTypes = nil
+	file_errxpb_errx_proto_depIdxs = nil
+}
@@ -0,0 +1,117 @@
+// Package errxpb converts errx errors to and from the Error protocol buffer message defined in
+// errx.proto, for services in other languages to consume.
+// It is kept separate from the errx package so that the protocol buffer dependency stays out of the core.
+package errxpb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative ../errxpb/errx.proto
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/hamidghavidel/errx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToProto converts the given error to an Error message.
+// For a CustomError, the message holds its message, codes and the message of its base error as
+// the cause, along with the fields and details collected from the whole chain. Fields that cannot
+// be represented as a protocol buffer value are converted to their fmt.Sprint form, and only the
+// details that are protocol buffer messages are included.
+// Any other error is converted to a message holding only its message.
+// It returns nil for a nil error.
+func ToProto(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	customErr, ok := errx.AsCustom(err)
+	if !ok {
+		return &Error{Message: err.Error()}
+	}
+
+	result := &Error{
+		Message:    customErr.Message,
+		HttpCode:   int32(customErr.HTTPCode),
+		CustomCode: int32(customErr.CustomCode),
+		Code:       customErr.Code,
+	}
+
+	if cause := customErr.Cause(); cause != nil {
+		result.Cause = cause.Error()
+	}
+
+	if fields := errx.Fields(customErr); len(fields) > 0 {
+		result.Fields = &structpb.Struct{Fields: make(map[string]*structpb.Value, len(fields))}
+		for key, value := range fields {
+			result.Fields.Fields[key] = toValue(value)
+		}
+	}
+
+	for _, detail := range errx.Details(customErr) {
+		message, ok := detail.(proto.Message)
+		if !ok {
+			continue
+		}
+
+		packed, err := anypb.New(message)
+		if err != nil {
+			continue
+		}
+
+		result.Details = append(result.Details, packed)
+	}
+
+	return result
+}
+
+// FromProto converts the given Error message back to a CustomError.
+// As the type of the original base error is unknown, the cause is restored as a plain error
+// carrying the serialized message. Fields are restored as returned by structpb.Struct.AsMap,
+// so numbers become float64 values. Details are unpacked into their message types when those
+// are registered, and kept as *anypb.Any values otherwise.
+// It returns nil for a nil message.
+func FromProto(pb *Error) error {
+	if pb == nil {
+		return nil
+	}
+
+	result := errx.NewCustom(pb.GetMessage())
+	result.HTTPCode = int(pb.GetHttpCode())
+	result.CustomCode = int(pb.GetCustomCode())
+	result.Code = pb.GetCode()
+
+	if pb.GetFields() != nil {
+		result.Fields = pb.GetFields().AsMap()
+	}
+
+	for _, packed := range pb.GetDetails() {
+		detail, err := packed.UnmarshalNew()
+		if err != nil {
+			result.Details = append(result.Details, packed)
+
+			continue
+		}
+
+		result.Details = append(result.Details, detail)
+	}
+
+	if pb.GetCause() != "" {
+		return errx.WithBase(stderrors.New(pb.GetCause()))(result)
+	}
+
+	return result
+}
+
+// toValue converts the given field value to a protocol buffer value, falling back to its
+// fmt.Sprint form if it has no protocol buffer representation.
+func toValue(value any) *structpb.Value {
+	result, err := structpb.NewValue(value)
+	if err != nil {
+		return structpb.NewStringValue(fmt.Sprint(value))
+	}
+
+	return result
+}
@@ -0,0 +1,75 @@
+package errxpb
+
+import (
+	stderrors "errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/hamidghavidel/errx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var errSentinel = stderrors.New("sentinel")
+
+func TestRoundTrip(t *testing.T) {
+	original := errx.Wrap(errSentinel, "lookup failed",
+		errx.WithHTTPCode(404), errx.WithCustomCode(1001), errx.WithCode("users.not_found"),
+		errx.WithFields(map[string]any{"user": "alice", "attempts": 3, "elapsed": 2 * time.Second}),
+		errx.WithDetails(durationpb.New(time.Minute), "not a proto message"))
+
+	data, err := proto.Marshal(ToProto(original))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+
+	var pb Error
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+
+	got, ok := errx.AsCustom(FromProto(&pb))
+	if !ok {
+		t.Fatalf("FromProto() = %v, want a CustomError", got)
+	}
+	if got.Message != "lookup failed" || got.HTTPCode != 404 || got.CustomCode != 1001 || got.Code != "users.not_found" {
+		t.Errorf("codes = %q %d %d %q, want lookup failed 404 1001 users.not_found", got.Message, got.HTTPCode, got.CustomCode, got.Code)
+	}
+	if got.Error() != original.Error() {
+		t.Errorf("Error() = %q, want %q", got.Error(), original.Error())
+	}
+
+	wantFields := map[string]any{"user": "alice", "attempts": 3.0, "elapsed": "2s"}
+	if !maps.Equal(got.Fields, wantFields) {
+		t.Errorf("Fields = %v, want %v", got.Fields, wantFields)
+	}
+
+	if len(got.Details) != 1 {
+		t.Fatalf("Details = %v, want the proto detail only", got.Details)
+	}
+	if detail, ok := got.Details[0].(*durationpb.Duration); !ok || detail.AsDuration() != time.Minute {
+		t.Errorf("Details[0] = %v, want a 1m duration", got.Details[0])
+	}
+}
+
+func TestToProtoPlainError(t *testing.T) {
+	pb := ToProto(errSentinel)
+	if pb.GetMessage() != "sentinel" || pb.GetHttpCode() != 0 || pb.GetCause() != "" {
+		t.Errorf("ToProto() = %v, want the message only", pb)
+	}
+
+	err := FromProto(pb)
+	if err.Error() != "sentinel" {
+		t.Errorf("FromProto() = %q, want %q", err.Error(), "sentinel")
+	}
+}
+
+func TestNil(t *testing.T) {
+	if ToProto(nil) != nil {
+		t.Error("ToProto(nil) is not nil")
+	}
+	if FromProto(nil) != nil {
+		t.Error("FromProto(nil) is not nil")
+	}
+}
@@ -0,0 +1,133 @@
+package errx
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// WithFields returns a Property that attaches structured key/value metadata to an error.
+// If the error is a CustomError, the given fields are merged into its existing fields,
+// with the given values replacing any existing ones under the same key.
+// Otherwise, it creates a new CustomError with the specified fields.
+func WithFields(fields map[string]any) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			if customErr.Fields == nil {
+				customErr.Fields = make(map[string]any, len(fields))
+			}
+
+			maps.Copy(customErr.Fields, fields)
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Fields:  maps.Clone(fields),
+		}
+	}
+}
+
+// WithGroup returns a Property that attaches the given fields under a named group, so that they
+// cannot collide with the fields of other groups and are serialized as a nested object.
+// The group is stored in the fields of the error as a map[string]any under the given name,
+// replacing any other value under that name. If the error already has the group, the given
+// fields are merged into it, with the given values replacing any existing ones under the same key.
+// If the error is not a CustomError, it creates a new CustomError with the specified group.
+func WithGroup(name string, fields map[string]any) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if !errors.As(err, &customErr) {
+			customErr = &CustomError{Message: err.Error()}
+			err = customErr
+		}
+
+		group, _ := customErr.Fields[name].(map[string]any)
+		group = maps.Clone(group)
+		if group == nil {
+			group = make(map[string]any, len(fields))
+		}
+
+		maps.Copy(group, fields)
+
+		if customErr.Fields == nil {
+			customErr.Fields = make(map[string]any)
+		}
+
+		customErr.Fields[name] = group
+
+		return err
+	}
+}
+
+// WithContextValues returns a Property that copies the values stored under the given keys in the
+// context of an error into its fields. Each value is stored under the key formatted with fmt.Sprint,
+// and keys that have no value in the context are skipped.
+// The context must already be set on the error, for example by applying WithContext first.
+// If the error is not a CustomError, it is returned unchanged.
+func WithContextValues(keys ...any) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if !errors.As(err, &customErr) || customErr.CTX == nil {
+			return err
+		}
+
+		for _, key := range keys {
+			value := customErr.CTX.Value(key)
+			if value == nil {
+				continue
+			}
+
+			if customErr.Fields == nil {
+				customErr.Fields = make(map[string]any)
+			}
+
+			customErr.Fields[fmt.Sprint(key)] = value
+		}
+
+		return err
+	}
+}
+
+// Fields collects the fields of every CustomError in err's chain.
+// When the same key is set on several layers, the value of the outermost layer is kept.
+// The result is a new map, and so are the groups set via WithGroup within it, so it can be
+// modified without affecting the error, even while the error is shared between goroutines.
+// Other field values are not copied.
+// It returns nil if no fields are set.
+func Fields(err error) map[string]any {
+	var result map[string]any
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		for key, value := range customErr.Fields {
+			if result == nil {
+				result = make(map[string]any)
+			}
+
+			if _, ok := result[key]; ok {
+				continue
+			}
+
+			if group, ok := value.(map[string]any); ok {
+				value = maps.Clone(group)
+			}
+
+			result[key] = value
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return result
+}
+
+// sortedKeys returns the keys of the given fields in sorted order, so that fields are always
+// rendered deterministically.
+func sortedKeys(fields map[string]any) []string {
+	return slices.Sorted(maps.Keys(fields))
+}
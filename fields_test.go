@@ -0,0 +1,161 @@
+package errx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestWithFieldsMerges(t *testing.T) {
+	err := New("boom",
+		WithFields(map[string]any{"user_id": 1, "region": "eu"}),
+		WithFields(map[string]any{"region": "us", "order_id": 7}),
+	)
+
+	want := map[string]any{"user_id": 1, "region": "us", "order_id": 7}
+	if got := Fields(err); !maps.Equal(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+}
+
+func TestFieldsCollectsChain(t *testing.T) {
+	inner := New("inner", WithFields(map[string]any{"user_id": 1, "region": "eu"}))
+	err := Wrap(inner, "outer", WithFields(map[string]any{"region": "us"}))
+
+	want := map[string]any{"user_id": 1, "region": "us"}
+	if got := Fields(err); !maps.Equal(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+
+	if got := Fields(errSentinel); got != nil {
+		t.Errorf("Fields() of a plain error = %v, want nil", got)
+	}
+
+	data, _ := json.Marshal(err)
+	if want := `{"message":"outer","cause":"inner","fields":{"region":"us","user_id":1}}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestWithContextValues(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("trace_id"), "abc")
+	ctx = context.WithValue(ctx, ctxKey("user_id"), 42)
+
+	err := New("boom", WithContext(ctx), WithContextValues(ctxKey("trace_id"), ctxKey("user_id"), ctxKey("region")))
+
+	want := map[string]any{"trace_id": "abc", "user_id": 42}
+	if got := Fields(err); !maps.Equal(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+
+	if got := WithContextValues(ctxKey("trace_id"))(errSentinel); got != errSentinel {
+		t.Errorf("WithContextValues() on a plain error = %v, want it unchanged", got)
+	}
+}
+
+func TestFieldsSortedOutput(t *testing.T) {
+	first := New("boom", WithFields(map[string]any{"zeta": 1}), WithFields(map[string]any{"alpha": 2}), WithFields(map[string]any{"mid": 3}))
+	second := New("boom", WithFields(map[string]any{"mid": 3}), WithFields(map[string]any{"zeta": 1}), WithFields(map[string]any{"alpha": 2}))
+
+	const wantJSON = `{"message":"boom","fields":{"alpha":2,"mid":3,"zeta":1}}`
+	want := []string{"alpha", "mid", "zeta"}
+
+	for _, err := range []error{first, second} {
+		data, _ := json.Marshal(err)
+		if string(data) != wantJSON {
+			t.Errorf("json.Marshal() = %s, want %s", data, wantJSON)
+		}
+
+		var keys []string
+		for _, attr := range err.(*CustomError).LogValue().Group() {
+			if attr.Key != "fields" {
+				continue
+			}
+
+			for _, field := range attr.Value.Group() {
+				keys = append(keys, field.Key)
+			}
+		}
+		if !slices.Equal(keys, want) {
+			t.Errorf("LogValue() field keys = %v, want %v", keys, want)
+		}
+	}
+}
+
+func TestWithGroupNoCollisions(t *testing.T) {
+	err := New("boom",
+		WithGroup("db", map[string]any{"host": "db-1", "timeout": 5}),
+		WithGroup("http", map[string]any{"host": "api.example.com"}),
+		WithGroup("db", map[string]any{"timeout": 10}),
+	)
+
+	data, _ := json.Marshal(err)
+	if want := `{"message":"boom","fields":{"db":{"host":"db-1","timeout":10},"http":{"host":"api.example.com"}}}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})).Error("failed", "error", err)
+
+	want := `{"level":"ERROR","msg":"failed","error":{"message":"boom","fields":{"db":{"host":"db-1","timeout":10},"http":{"host":"api.example.com"}}}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("log line = %s, want %s", buf.String(), want)
+	}
+}
+
+func TestFieldsReturnsCopy(t *testing.T) {
+	err := New("boom", WithFields(map[string]any{"user_id": 1}), WithGroup("db", map[string]any{"host": "db-1"}))
+
+	fields := Fields(err)
+	fields["user_id"] = 2
+	fields["db"].(map[string]any)["host"] = "db-2"
+
+	if got := Fields(err); got["user_id"] != 1 || got["db"].(map[string]any)["host"] != "db-1" {
+		t.Errorf("Fields() after modifying a previous result = %v, want it unchanged", got)
+	}
+}
+
+// TestFieldsConcurrent is meant to be run with -race.
+func TestFieldsConcurrent(t *testing.T) {
+	shared := New("boom", WithFields(map[string]any{"user_id": 1}), WithGroup("db", map[string]any{"host": "db-1"}))
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			fields := Fields(shared)
+			fields["user_id"] = i
+			fields["db"].(map[string]any)["host"] = i
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			derived := Wrap(shared.(*CustomError).Clone(), "derived", WithFields(map[string]any{"attempt": i}), WithGroup("db", map[string]any{"port": i}))
+			if got := Fields(derived)["attempt"]; got != i {
+				t.Errorf("Fields() of a derived error has attempt %v, want %d", got, i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := Fields(shared); got["user_id"] != 1 || got["db"].(map[string]any)["host"] != "db-1" || len(got) != 2 {
+		t.Errorf("Fields() of the shared error = %v, want it unchanged", got)
+	}
+}
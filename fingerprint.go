@@ -0,0 +1,59 @@
+package errx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/pkg/errors"
+)
+
+// Fingerprint returns a stable hash identifying the kind of the given error, meant for grouping
+// the occurrences of the same error in error-tracking backends.
+// The hash covers the message, HTTP code, custom code, code and category of every CustomError
+// in err's chain, along with the type of the root error. Volatile data such as timestamps,
+// request IDs, fields and the message of the base error is excluded.
+// Messages built by Newf, Wrapf or Wrapw are hashed as the format strings they were built from,
+// so that Newf("user %d not found", 1) and Newf("user %d not found", 2) share a fingerprint.
+// If err does not contain any CustomError, the hash covers its message instead.
+// It returns an empty string for a nil error.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	h := sha256.New()
+	writeFingerprintPart(h, fmt.Sprintf("%T", Root(err)))
+
+	found := false
+	chain := err
+
+	var customErr *CustomError
+	for errors.As(chain, &customErr) {
+		found = true
+		if customErr.template != "" {
+			writeFingerprintPart(h, customErr.template)
+		} else {
+			writeFingerprintPart(h, customErr.Message)
+		}
+		writeFingerprintPart(h, fmt.Sprint(customErr.HTTPCode))
+		writeFingerprintPart(h, fmt.Sprint(customErr.CustomCode))
+		writeFingerprintPart(h, customErr.Code)
+		writeFingerprintPart(h, customErr.Category)
+
+		chain = customErr.Unwrap()
+	}
+
+	if !found {
+		writeFingerprintPart(h, err.Error())
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeFingerprintPart writes the given part of a fingerprint to h, followed by a separator.
+func writeFingerprintPart(h hash.Hash, part string) {
+	_, _ = h.Write([]byte(part))
+	_, _ = h.Write([]byte{0})
+}
@@ -0,0 +1,67 @@
+package errx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFingerprint(t *testing.T) {
+	newErr := func(msg, requestID string, userID int) error {
+		return Wrap(errSentinel, msg, WithHTTPCode(404), WithCategory("database"),
+			WithRequestID(requestID), WithFields(map[string]any{"user_id": userID}))
+	}
+
+	a := Fingerprint(newErr("user not found", "req-1", 1))
+	b := Fingerprint(newErr("user not found", "req-2", 2))
+	if a == "" || a != b {
+		t.Errorf("fingerprints of errors differing by request ID and fields = %q and %q, want equal", a, b)
+	}
+
+	if c := Fingerprint(newErr("order not found", "req-1", 1)); c == a {
+		t.Error("errors with different messages share a fingerprint")
+	}
+	if d := Fingerprint(Wrap(errSentinel, "user not found", WithHTTPCode(500), WithCategory("database"))); d == a {
+		t.Error("errors with different HTTP codes share a fingerprint")
+	}
+	if Fingerprint(nil) != "" {
+		t.Error("Fingerprint(nil) is not empty")
+	}
+}
+
+func TestFingerprintTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b error
+	}{
+		{"Newf", Newf("user %d not found", 1), Newf("user %d not found", 2)},
+		{"Wrapf", Wrapf(errSentinel, "loading user %d", 1), Wrapf(errSentinel, "loading user %d", 2)},
+		{"Wrapw", Wrapw(errSentinel, "loading user %d: %w", 1), Wrapw(errSentinel, "loading user %d: %w", 2)},
+		{"wrapped", Wrap(Newf("user %d not found", 1), "lookup failed"), Wrap(Newf("user %d not found", 2), "lookup failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Fingerprint(tt.a) != Fingerprint(tt.b) {
+				t.Error("errors built from the same template have different fingerprints")
+			}
+		})
+	}
+
+	if Fingerprint(Newf("user %d not found", 1)) == Fingerprint(Newf("order %d not found", 1)) {
+		t.Error("errors built from different templates share a fingerprint")
+	}
+	if Fingerprint(WithReplaceMessage("user not found")(Newf("user %d not found", 1))) == Fingerprint(Newf("user %d not found", 1)) {
+		t.Error("replacing the message kept the template")
+	}
+}
+
+func TestSamplerGroupsTemplates(t *testing.T) {
+	sampler := NewSampler(1, time.Minute)
+
+	if !sampler.ShouldReport(Newf("user %d not found", 1)) {
+		t.Error("ShouldReport() of the first error = false, want true")
+	}
+	if sampler.ShouldReport(Newf("user %d not found", 2)) {
+		t.Error("ShouldReport() of an error built from the same template = true, want false")
+	}
+}
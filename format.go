@@ -0,0 +1,134 @@
+package errx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format implements fmt.Formatter for the CustomError.
+// The %s and %v verbs print the same one-line form as Error, and %q prints it quoted.
+// The %+v verb prints the message chain from the outermost layer down to the base error,
+// one message per line, followed by the frames of any stack captured via WithStack.
+// A nil CustomError is printed as "<nil>".
+func (e *CustomError) Format(s fmt.State, verb rune) {
+	if e == nil {
+		_, _ = io.WriteString(s, "<nil>")
+
+		return
+	}
+
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, e.renderedMessage())
+			for _, frame := range e.StackTrace() {
+				_, _ = fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+
+			if e.base != nil {
+				if e.renderedMessage() != "" || len(e.StackTrace()) > 0 {
+					_, _ = io.WriteString(s, "\n")
+				}
+
+				_, _ = fmt.Fprintf(s, "%+v", e.base)
+			}
+
+			return
+		}
+
+		fallthrough
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// Display returns a multi-line description of err for humans, such as in CLI or debug output.
+// Each CustomError in the chain is rendered on its own line, from the outermost to the innermost,
+// with its message followed by its HTTP code, custom code and code when set, and indented one
+// level deeper than the layer wrapping it. The frames of any stack captured via WithStack are
+// listed below their layer. If the innermost CustomError wraps another error, the message of that
+// error is rendered as the last layer, unless the innermost CustomError has an empty message, such
+// as the layer added by Enrich, in which case both are rendered on a single line with the message
+// of the wrapped error. The format is not meant to be parsed.
+// An error without any CustomError is rendered as its message, and a nil error as an empty string.
+func Display(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	depth := 0
+
+	var customErr *CustomError
+	for depth < maxChainDepth && errors.As(err, &customErr) {
+		message, merged := layerMessage(customErr)
+		writeDisplayLine(&b, depth, strings.TrimPrefix(message+displayCodes(customErr), " "))
+		for _, frame := range customErr.StackTrace() {
+			writeDisplayLine(&b, depth+1, fmt.Sprintf("at %s (%s:%d)", frame.Function, frame.File, frame.Line))
+		}
+
+		depth++
+
+		err = customErr.Unwrap()
+		if err != nil && !merged && !errors.As(err, new(*CustomError)) {
+			writeDisplayLine(&b, depth, err.Error())
+		}
+	}
+
+	if depth == 0 {
+		return err.Error()
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// layerMessage returns the message of the given layer as rendered by Display and Flatten.
+// A layer with an empty message wrapping an error that is not a CustomError, such as the layer
+// added by Enrich, takes the message of that error, which is then not rendered as a layer of its own;
+// the boolean result reports whether it did.
+func layerMessage(e *CustomError) (string, bool) {
+	if e.Message == "" && e.base != nil && !errors.As(e.base, new(*CustomError)) {
+		return e.base.Error(), true
+	}
+
+	return e.Message, false
+}
+
+// displayCodes returns the codes of the given layer as rendered by Display, or an empty string
+// if it has none.
+func displayCodes(e *CustomError) string {
+	var codes []string
+	if e.HTTPCode != 0 {
+		codes = append(codes, fmt.Sprintf("http %d", e.HTTPCode))
+	}
+
+	if e.CustomCode != 0 {
+		codes = append(codes, fmt.Sprintf("custom %d", e.CustomCode))
+	}
+
+	if e.Code != "" {
+		codes = append(codes, "code "+e.Code)
+	}
+
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return " [" + strings.Join(codes, ", ") + "]"
+}
+
+// writeDisplayLine writes each line of the given text to b, indented by two spaces per depth level.
+func writeDisplayLine(b *strings.Builder, depth int, text string) {
+	indent := strings.Repeat("  ", depth)
+	for line := range strings.SplitSeq(text, "\n") {
+		b.WriteString(indent)
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+}
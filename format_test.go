@@ -0,0 +1,46 @@
+package errx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	err := Wrap(New("inner", WithHTTPCode(404)), "outer")
+
+	if got, want := fmt.Sprintf("%v", err), "inner: outer"; got != want {
+		t.Errorf("%%v = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%s", err), "inner: outer"; got != want {
+		t.Errorf("%%s = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%+v", err), "outer\ninner"; got != want {
+		t.Errorf("%%+v = %q, want %q", got, want)
+	}
+}
+
+func TestDisplay(t *testing.T) {
+	inner := Wrap(errSentinel, "query failed", WithHTTPCode(503), WithCode("db.unavailable"))
+	err := Wrap(inner, "lookup failed", WithHTTPCode(500), WithCustomCode(1001))
+
+	want := "lookup failed [http 500, custom 1001, code db.unavailable]\n" +
+		"  query failed [http 503, code db.unavailable]\n" +
+		"    sentinel"
+	if got := Display(err); got != want {
+		t.Errorf("Display() = %q, want %q", got, want)
+	}
+
+	if got, want := Display(Enrich(errSentinel, WithHTTPCode(500))), "sentinel [http 500]"; got != want {
+		t.Errorf("Display() of an enriched error = %q, want %q", got, want)
+	}
+	if got, want := Display(Wrap(Enrich(errSentinel, WithHTTPCode(500)), "lookup failed")), "lookup failed [http 500]\n  sentinel [http 500]"; got != want {
+		t.Errorf("Display() of a wrapped enriched error = %q, want %q", got, want)
+	}
+
+	if got := Display(errSentinel); got != "sentinel" {
+		t.Errorf("Display() of a plain error = %q, want %q", got, "sentinel")
+	}
+	if got := Display(nil); got != "" {
+		t.Errorf("Display(nil) = %q, want an empty string", got)
+	}
+}
@@ -0,0 +1,61 @@
+package errx
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// WithHTTPHeaders returns a Property that sets response headers specific to an error, such as
+// Retry-After for a 503 or WWW-Authenticate for a 401, which errxhttp.WriteHTTP writes along
+// with the error.
+// If the error is a CustomError, the given headers are merged into its existing headers,
+// with the given values replacing any existing ones under the same key.
+// Otherwise, it creates a new CustomError with the specified headers.
+func WithHTTPHeaders(headers http.Header) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if !errors.As(err, &customErr) {
+			customErr = &CustomError{Message: err.Error()}
+			err = customErr
+		}
+
+		merged := customErr.Headers.Clone()
+		if merged == nil {
+			merged = make(http.Header, len(headers))
+		}
+
+		for key, values := range headers.Clone() {
+			merged[http.CanonicalHeaderKey(key)] = values
+		}
+
+		customErr.Headers = merged
+
+		return err
+	}
+}
+
+// HTTPHeaders collects the headers of every CustomError in err's chain.
+// When the same header is set on several layers, the values of the outermost layer are kept.
+// The result is a new http.Header that can be modified without affecting the error.
+// It returns nil if no headers are set.
+func HTTPHeaders(err error) http.Header {
+	var result http.Header
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		for key, values := range customErr.Headers {
+			if result == nil {
+				result = make(http.Header)
+			}
+
+			if _, ok := result[key]; !ok {
+				result[key] = append([]string(nil), values...)
+			}
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return result
+}
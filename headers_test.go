@@ -0,0 +1,44 @@
+package errx
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHTTPHeaders(t *testing.T) {
+	inner := New("unauthorized", WithHTTPHeaders(http.Header{
+		"www-authenticate": {`Bearer realm="api"`},
+		"Retry-After":      {"60"},
+	}))
+	err := Wrap(inner, "request failed", WithHTTPHeaders(http.Header{"Retry-After": {"120"}}))
+
+	want := http.Header{
+		"Www-Authenticate": {`Bearer realm="api"`},
+		"Retry-After":      {"120"},
+	}
+	got := HTTPHeaders(err)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HTTPHeaders() = %v, want %v", got, want)
+	}
+
+	got.Set("Retry-After", "1")
+	if HTTPHeaders(err).Get("Retry-After") != "120" {
+		t.Error("modifying the result of HTTPHeaders() changed the error")
+	}
+
+	if got := HTTPHeaders(New("boom", WithHTTPCode(500))); got != nil {
+		t.Errorf("HTTPHeaders() without headers = %v, want nil", got)
+	}
+}
+
+func TestWithHTTPHeadersMerges(t *testing.T) {
+	headers := http.Header{"Retry-After": {"60"}}
+	err := New("unavailable", WithHTTPHeaders(headers), WithHTTPHeaders(http.Header{"X-Reason": {"maintenance"}}))
+	headers.Set("Retry-After", "1")
+
+	want := http.Header{"Retry-After": {"60"}, "X-Reason": {"maintenance"}}
+	if got := HTTPHeaders(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("HTTPHeaders() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,39 @@
+package errx
+
+import "github.com/pkg/errors"
+
+// WithHint returns a Property that sets the hint of an error.
+// A hint is a user-facing suggestion for resolving the error, such as "check your API key",
+// kept separate from the technical message.
+// If the error is a CustomError, it updates the Hint of the existing error.
+// Otherwise, it creates a new CustomError with the specified hint.
+func WithHint(hint string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Hint = hint
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Hint:    hint,
+		}
+	}
+}
+
+// Hint returns the hint of the outermost CustomError in err's chain that has one set.
+// The boolean result reports whether such a hint was found.
+func Hint(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Hint != "" {
+			return customErr.Hint, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
@@ -0,0 +1,28 @@
+package errx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHint(t *testing.T) {
+	err := Wrap(New("unauthorized", WithHint("check your API key")), "request failed")
+
+	if got, ok := Hint(err); got != "check your API key" || !ok {
+		t.Errorf("Hint() = (%q, %t), want the inner hint", got, ok)
+	}
+
+	data, _ := json.Marshal(err)
+	if !strings.Contains(string(data), `"hint":"check your API key"`) {
+		t.Errorf("json.Marshal() = %s, want a hint key", data)
+	}
+
+	data, _ = json.Marshal(New("boom", WithHTTPCode(500)))
+	if strings.Contains(string(data), "hint") {
+		t.Errorf("json.Marshal() without a hint = %s, want no hint key", data)
+	}
+	if _, ok := Hint(errSentinel); ok {
+		t.Error("Hint() of a plain error = true, want false")
+	}
+}
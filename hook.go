@@ -0,0 +1,94 @@
+package errx
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// callHookFunc is the name of the function that invokes the error hook, used to detect
+// errors created by the hook itself.
+const callHookFunc = pkgPath + ".callHook"
+
+var (
+	errorHookMu sync.RWMutex
+	errorHook   func(*CustomError)
+
+	// activeHooks is the number of calls to the error hook in progress on any goroutine.
+	activeHooks atomic.Int32
+)
+
+// OnError sets a hook that New, Wrap and Wrapw call with every CustomError they return,
+// after all properties have been applied, for example to count errors by code or category.
+// It replaces any previously set hook, and calling it with nil removes it.
+// Errors created by the hook itself do not trigger it again, and a panic raised by the hook
+// is recovered and ignored, so the hook cannot break error creation. The hook must not
+// modify the error.
+// It is safe for concurrent use, but it is meant to be called once during initialization.
+func OnError(hook func(*CustomError)) {
+	errorHookMu.Lock()
+	defer errorHookMu.Unlock()
+
+	errorHook = hook
+}
+
+// notifyError calls the error hook with err if it is a CustomError, unless it is being created
+// by the hook itself.
+func notifyError(err error) {
+	errorHookMu.RLock()
+	hook := errorHook
+	errorHookMu.RUnlock()
+
+	if hook == nil {
+		return
+	}
+
+	customErr, ok := err.(*CustomError)
+	if !ok || inHook() {
+		return
+	}
+
+	callHook(hook, customErr)
+}
+
+// callHook calls the given hook with the given error, recovering from any panic it raises.
+func callHook(hook func(*CustomError), err *CustomError) {
+	activeHooks.Add(1)
+	defer activeHooks.Add(-1)
+	defer func() { _ = recover() }()
+
+	hook(err)
+}
+
+// inHook reports whether the current goroutine is running the error hook.
+// As it has to inspect the stack of the goroutine, it first checks whether the hook is running
+// at all, so that the stack is only inspected while some goroutine is running the hook.
+func inHook() bool {
+	if activeHooks.Load() == 0 {
+		return false
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	for {
+		n := runtime.Callers(3, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+
+			break
+		}
+
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function == callHookFunc {
+			return true
+		}
+
+		if !more {
+			return false
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package errx
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestOnErrorFiresOnce(t *testing.T) {
+	var got []*CustomError
+	OnError(func(err *CustomError) { got = append(got, err) })
+	defer OnError(nil)
+
+	created := New("not found", WithHTTPCode(404), WithCustomCode(1001))
+	wrapped := Wrap(created, "lookup failed", WithCategory("database"))
+	formatted := Wrapw(errSentinel, "%w: loading %s", "config")
+	_ = New("plain")
+	_ = Wrap(errSentinel, "plain wrap")
+
+	want := []error{created, wrapped, formatted}
+	if len(got) != len(want) {
+		t.Fatalf("hook fired %d times, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got[0].HTTPCode != 404 || got[0].CustomCode != 1001 {
+		t.Errorf("hook saw codes %d and %d, want 404 and 1001", got[0].HTTPCode, got[0].CustomCode)
+	}
+	if got[1].Category != "database" {
+		t.Errorf("hook saw category %q, want the one applied by the properties", got[1].Category)
+	}
+}
+
+func TestOnErrorRecursionAndPanic(t *testing.T) {
+	calls := 0
+	OnError(func(err *CustomError) {
+		calls++
+		_ = New("created by the hook", WithHTTPCode(500))
+		panic("hook failure")
+	})
+	defer OnError(nil)
+
+	err := New("boom", WithHTTPCode(500))
+	if err == nil || calls != 1 {
+		t.Errorf("hook fired %d times, want 1", calls)
+	}
+	if activeHooks.Load() != 0 {
+		t.Errorf("activeHooks = %d after the hook returned, want 0", activeHooks.Load())
+	}
+}
+
+func TestOnErrorConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	OnError(func(*CustomError) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+	})
+	defer OnError(nil)
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = New("boom", WithHTTPCode(500))
+		}()
+	}
+	wg.Wait()
+
+	if calls != 50 {
+		t.Errorf("hook fired %d times, want 50", calls)
+	}
+}
+
+func BenchmarkNewWithHook(b *testing.B) {
+	OnError(func(*CustomError) {})
+	defer OnError(nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = New("boom", WithHTTPCode(500))
+	}
+}
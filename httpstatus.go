@@ -0,0 +1,93 @@
+package errx
+
+import "fmt"
+
+const (
+	minHTTPStatus = 100
+	maxHTTPStatus = 599
+)
+
+var (
+	// ServerError matches, via Is, any error whose HTTP code is 500 or above.
+	ServerError error = &httpCodeClass{name: "server error", min: 500, max: maxHTTPStatus}
+	// ClientError matches, via Is, any error whose HTTP code is between 400 and 499.
+	ClientError error = &httpCodeClass{name: "client error", min: 400, max: 499}
+)
+
+// httpCodeClass is a target for Is that matches errors by the range of their HTTP code.
+// The HTTP code is the one set on the chain, or else the one mapped to its category in
+// CategoryHTTPCodes; errors without either never match.
+type httpCodeClass struct {
+	name     string
+	min, max int
+}
+
+// Error returns the name of the class.
+func (c *httpCodeClass) Error() string {
+	return c.name
+}
+
+// matches reports whether the HTTP code of err falls within the class.
+func (c *httpCodeClass) matches(err error) bool {
+	httpCode, ok := mappedHTTPCode(err)
+
+	return ok && httpCode >= c.min && httpCode <= c.max
+}
+
+// HTTPStatusPolicy determines how WithHTTPStatus handles codes outside the valid HTTP status range.
+type HTTPStatusPolicy int
+
+const (
+	// IgnoreInvalidHTTPStatus leaves the error unchanged.
+	IgnoreInvalidHTTPStatus HTTPStatusPolicy = iota
+	// ClampInvalidHTTPStatus sets the nearest valid HTTP status instead.
+	ClampInvalidHTTPStatus
+	// PanicOnInvalidHTTPStatus panics, which is meant to catch mistakes early in debug builds.
+	PanicOnInvalidHTTPStatus
+)
+
+// InvalidHTTPStatusPolicy is the policy applied by WithHTTPStatus to invalid codes.
+// It defaults to IgnoreInvalidHTTPStatus and should be configured at init time.
+var InvalidHTTPStatusPolicy = IgnoreInvalidHTTPStatus
+
+// WithHTTPStatus returns a Property that sets the HTTP code of an error like WithHTTPCode,
+// after validating that the code is a valid HTTP status between 100 and 599.
+// Invalid codes are handled according to InvalidHTTPStatusPolicy.
+func WithHTTPStatus(code int) Property {
+	return func(err error) error {
+		if code >= minHTTPStatus && code <= maxHTTPStatus {
+			return WithHTTPCode(code)(err)
+		}
+
+		switch InvalidHTTPStatusPolicy {
+		case ClampInvalidHTTPStatus:
+			return WithHTTPCode(min(max(code, minHTTPStatus), maxHTTPStatus))(err)
+		case PanicOnInvalidHTTPStatus:
+			panic(fmt.Sprintf("errx: invalid HTTP status %d", code))
+		default:
+			return err
+		}
+	}
+}
+
+// Transform returns an error whose HTTP code is remapped according to the given table, which is
+// meant for converting internal codes into client-facing ones at a trust boundary.
+// The result is a copy of the first CustomError in err's chain with the remapped HTTP code,
+// so its base error and the rest of the chain are preserved for internal logging. If that
+// CustomError is embedded in a ValidationError, the result is a copy of the ValidationError.
+// If the HTTP code of err is not in the table, err is returned unchanged.
+func Transform(err error, mapping map[int]int) error {
+	httpCode, ok := GetHTTPCode(err)
+	if !ok {
+		return err
+	}
+
+	mapped, ok := mapping[httpCode]
+	if !ok {
+		return err
+	}
+
+	return cloneFirstCustom(err, func(customErr *CustomError) {
+		customErr.HTTPCode = mapped
+	})
+}
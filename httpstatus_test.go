@@ -0,0 +1,114 @@
+package errx
+
+import "testing"
+
+func TestWithHTTPStatus(t *testing.T) {
+	defer func() { InvalidHTTPStatusPolicy = IgnoreInvalidHTTPStatus }()
+
+	tests := []struct {
+		name     string
+		policy   HTTPStatusPolicy
+		code     int
+		want     int
+		wantCode bool
+	}{
+		{"valid", IgnoreInvalidHTTPStatus, 404, 404, true},
+		{"ignore", IgnoreInvalidHTTPStatus, 9999, 0, false},
+		{"clamp high", ClampInvalidHTTPStatus, 9999, 599, true},
+		{"clamp low", ClampInvalidHTTPStatus, 42, 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			InvalidHTTPStatusPolicy = tt.policy
+
+			got, ok := GetHTTPCode(New("boom", WithHTTPStatus(tt.code)))
+			if got != tt.want || ok != tt.wantCode {
+				t.Errorf("GetHTTPCode() = (%d, %t), want (%d, %t)", got, ok, tt.want, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWithHTTPStatusPanic(t *testing.T) {
+	InvalidHTTPStatusPolicy = PanicOnInvalidHTTPStatus
+	defer func() { InvalidHTTPStatusPolicy = IgnoreInvalidHTTPStatus }()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("WithHTTPStatus(9999) did not panic")
+		}
+	}()
+
+	_ = New("boom", WithHTTPStatus(9999))
+}
+
+func TestTransform(t *testing.T) {
+	mapping := map[int]int{503: 500}
+
+	unavailable := Wrap(errSentinel, "db unavailable", WithHTTPCode(503), WithCustomCode(1001))
+	remapped := Transform(unavailable, mapping)
+	if code, _ := GetHTTPCode(remapped); code != 500 {
+		t.Errorf("GetHTTPCode() = %d, want 500", code)
+	}
+	if code, _ := GetCustomCode(remapped); code != 1001 {
+		t.Errorf("GetCustomCode() = %d, want 1001", code)
+	}
+	if !Is(remapped, errSentinel) {
+		t.Error("Transform() dropped the rest of the chain")
+	}
+	if code, _ := GetHTTPCode(unavailable); code != 503 {
+		t.Errorf("Transform() modified the original error: HTTP code %d, want 503", code)
+	}
+
+	notFound := New("not found", WithHTTPCode(404))
+	if got := Transform(notFound, mapping); got != notFound {
+		t.Errorf("Transform() of an unmapped code = %v, want the error unchanged", got)
+	}
+	if got := Transform(errSentinel, mapping); got != errSentinel {
+		t.Errorf("Transform() of a plain error = %v, want the error unchanged", got)
+	}
+}
+
+func TestTransformValidationError(t *testing.T) {
+	err := NewValidation(map[string]string{"email": "required"})
+
+	remapped := Transform(err, map[int]int{422: 400})
+	if code, _ := GetHTTPCode(remapped); code != 400 {
+		t.Errorf("GetHTTPCode() = %d, want 400", code)
+	}
+	if fields, ok := FieldErrors(remapped); !ok || fields["email"] != "required" {
+		t.Errorf("FieldErrors() = (%v, %t), want the field errors", fields, ok)
+	}
+	if _, ok := remapped.(*ValidationError); !ok {
+		t.Errorf("Transform() = %T, want *ValidationError", remapped)
+	}
+	if code, _ := GetHTTPCode(err); code != 422 {
+		t.Errorf("Transform() modified the original error: HTTP code %d, want 422", code)
+	}
+}
+
+func TestHTTPCodeClasses(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantServer bool
+		wantClient bool
+	}{
+		{"503", Wrap(New("unavailable", WithHTTPCode(503)), "outer"), true, false},
+		{"404", New("not found", WithHTTPCode(404)), false, true},
+		{"no code", New("boom", WithCustomCode(1)), false, false},
+		{"plain error", errSentinel, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(tt.err, ServerError); got != tt.wantServer {
+				t.Errorf("Is(ServerError) = %t, want %t", got, tt.wantServer)
+			}
+			if got := Is(tt.err, ClientError); got != tt.wantClient {
+				t.Errorf("Is(ClientError) = %t, want %t", got, tt.wantClient)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package errx
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MultiError is an error that aggregates several errors.
+// It implements Unwrap() []error, so errors.Is and errors.As match any of the contained errors.
+type MultiError struct {
+	errs []error
+}
+
+// Error returns the messages of the contained errors, one per line.
+func (m *MultiError) Error() string {
+	messages := make([]string, 0, len(m.errs))
+	for _, err := range m.errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors contained in the MultiError.
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
+
+// Join returns an error that aggregates the given errors, skipping any nil ones.
+// If every given error is nil, it returns nil.
+func Join(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+
+	if len(nonNil) == 0 {
+		return nil
+	}
+
+	return &MultiError{errs: nonNil}
+}
+
+// WithCauses returns a Property that sets the base error of an error to the given causes joined
+// via Join, replacing any existing base error, so that each cause is reachable through Is and As.
+// Nil causes are skipped, and if every cause is nil, the base error is removed.
+// If the error is a CustomError, it updates the base error of the existing error.
+// Otherwise, it creates a new CustomError with the specified causes.
+func WithCauses(causes ...error) Property {
+	return WithBase(Join(causes...))
+}
+
+// FirstError returns the first non-nil error of the given named errors, in the sorted order of
+// their names, wrapped in a CustomError carrying the name under the "task" field.
+// It returns nil if the map is nil or all of its errors are nil.
+func FirstError(named map[string]error) error {
+	for _, name := range slices.Sorted(maps.Keys(named)) {
+		err := named[name]
+		if err == nil {
+			continue
+		}
+
+		result := newLayer(err, fmt.Sprintf("task %q failed", name))
+		result.Fields = map[string]any{"task": name}
+
+		return result
+	}
+
+	return nil
+}
+
+// Summarize counts the errors aggregated by err by HTTP code, for example to log that a batch
+// failed with 8 errors of code 500 and 4 of code 404. The branches of nested joined errors are
+// counted individually, and errors without an HTTP code are counted under 0.
+// An error that is not a joined error counts as a single error.
+// It returns nil for a nil error.
+func Summarize(err error) map[int]int {
+	if err == nil {
+		return nil
+	}
+
+	result := make(map[int]int)
+	summarize(err, result, 0)
+
+	return result
+}
+
+// summarize adds the HTTP codes of err, or of its branches if it is a joined error, to counts.
+func summarize(err error, counts map[int]int, depth int) {
+	if err == nil || depth >= maxChainDepth {
+		return
+	}
+
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, branch := range multi.Unwrap() {
+			summarize(branch, counts, depth+1)
+		}
+
+		return
+	}
+
+	httpCode, _ := GetHTTPCode(err)
+	counts[httpCode]++
+}
@@ -0,0 +1,118 @@
+package errx
+
+import (
+	stderrors "errors"
+	"maps"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	other := stderrors.New("other")
+
+	err := Join(nil, New("first", WithHTTPCode(500)), Wrap(errSentinel, "second"), nil)
+	if !Is(err, errSentinel) {
+		t.Error("Is(err, errSentinel) = false, want true")
+	}
+	if Is(err, other) {
+		t.Error("Is(err, other) = true, want false")
+	}
+	if got, want := err.Error(), "first\nsecond: sentinel"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if err := Join(nil, nil); err != nil {
+		t.Errorf("Join(nil, nil) = %v, want nil", err)
+	}
+	if err := Join(); err != nil {
+		t.Errorf("Join() = %v, want nil", err)
+	}
+}
+
+func TestIsAsThroughJoin(t *testing.T) {
+	third := New("third", WithHTTPCode(404))
+	err := Join(stderrors.New("first"), Wrap(errSentinel, "second"), third)
+
+	if !Is(err, errSentinel) {
+		t.Error("Is() did not find the sentinel in the second branch")
+	}
+
+	var customErr *CustomError
+	if !As(err, &customErr) || customErr != third {
+		t.Errorf("As() = %v, want the CustomError of the third branch", customErr)
+	}
+}
+
+func TestWithCauses(t *testing.T) {
+	errDisk := stderrors.New("disk full")
+	errQuota := New("quota exceeded", WithHTTPCode(429))
+
+	err := New("upload failed", WithHTTPCode(500), WithCauses(errDisk, nil, errQuota, errSentinel))
+
+	for _, cause := range []error{errDisk, errQuota, errSentinel} {
+		if !Is(err, cause) || !stderrors.Is(err, cause) {
+			t.Errorf("Is(%v) = false, want true", cause)
+		}
+	}
+
+	causes := stderrors.Unwrap(err).(interface{ Unwrap() []error }).Unwrap()
+	if len(causes) != 3 {
+		t.Errorf("Unwrap() of the base error = %v, want the three causes", causes)
+	}
+
+	if cleared := WithCauses(nil, nil)(err); stderrors.Unwrap(cleared) != nil {
+		t.Errorf("base error after WithCauses(nil, nil) = %v, want nil", stderrors.Unwrap(cleared))
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	err := FirstError(map[string]error{
+		"beta":  New("beta failed", WithHTTPCode(500)),
+		"alpha": errSentinel,
+		"gamma": nil,
+	})
+
+	if !Is(err, errSentinel) {
+		t.Errorf("FirstError() = %v, want the error of alpha", err)
+	}
+	if task := Fields(err)["task"]; task != "alpha" {
+		t.Errorf("task field = %v, want alpha", task)
+	}
+
+	for i := 0; i < 10; i++ {
+		again := FirstError(map[string]error{"beta": New("beta failed", WithHTTPCode(500)), "alpha": errSentinel})
+		if !Is(again, errSentinel) {
+			t.Fatal("FirstError() did not select the first task by name")
+		}
+	}
+}
+
+func TestFirstErrorNone(t *testing.T) {
+	if err := FirstError(nil); err != nil {
+		t.Errorf("FirstError(nil) = %v, want nil", err)
+	}
+	if err := FirstError(map[string]error{"a": nil, "b": nil}); err != nil {
+		t.Errorf("FirstError() of nil errors = %v, want nil", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	err := Join(
+		New("a", WithHTTPCode(500)),
+		New("b", WithHTTPCode(404)),
+		Join(New("c", WithHTTPCode(500)), Wrap(New("d", WithHTTPCode(404)), "wrapped")),
+		New("e", WithHTTPCode(500)),
+		errSentinel,
+	)
+
+	want := map[int]int{500: 3, 404: 2, 0: 1}
+	if got := Summarize(err); !maps.Equal(got, want) {
+		t.Errorf("Summarize() = %v, want %v", got, want)
+	}
+
+	if got := Summarize(New("single", WithHTTPCode(503))); !maps.Equal(got, map[int]int{503: 1}) {
+		t.Errorf("Summarize() of a single error = %v, want map[503:1]", got)
+	}
+	if Summarize(nil) != nil {
+		t.Error("Summarize(nil) is not nil")
+	}
+}
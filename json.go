@@ -0,0 +1,186 @@
+package errx
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+)
+
+// jsonError is the serialized form of a CustomError.
+type jsonError struct {
+	Message    string           `json:"message,omitempty"`
+	HTTPCode   int              `json:"http_code,omitempty"`
+	CustomCode int              `json:"custom_code,omitempty"`
+	Code       string           `json:"code,omitempty"`
+	Cause      string           `json:"cause,omitempty"`
+	Fields     map[string]any   `json:"fields,omitempty"`
+	Timestamp  string           `json:"timestamp,omitempty"`
+	RequestID  string           `json:"request_id,omitempty"`
+	TraceID    string           `json:"trace_id,omitempty"`
+	SpanID     string           `json:"span_id,omitempty"`
+	Details    []json.Marshaler `json:"details,omitempty"`
+	Hint       string           `json:"hint,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+	Source     string           `json:"source,omitempty"`
+	Retryable  bool             `json:"retryable,omitempty"`
+	Attempt    int              `json:"attempt,omitempty"`
+	Severity   string           `json:"severity,omitempty"`
+	Category   string           `json:"category,omitempty"`
+	Tags       []string         `json:"tags,omitempty"`
+	Component  string           `json:"component,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler for the CustomError.
+// It emits the message, the codes, the message of the base error as the cause,
+// the fields collected from the whole chain, the creation time of the original error
+// formatted as RFC 3339, the nearest request, trace and span IDs, the details collected from the whole chain
+// that implement json.Marshaler, the nearest hint and reason, the innermost source, whether the
+// error is retryable, the nearest attempt number, the highest severity by name, the nearest
+// category, the tags collected from the whole chain and the nearest component, omitting any that
+// are empty.
+// Fields are emitted sorted by key. The context and any other details are never serialized.
+// A nil CustomError is serialized as null.
+func (e *CustomError) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(e.jsonPayload())
+}
+
+// jsonPayload returns the serialized form of the CustomError.
+func (e *CustomError) jsonPayload() jsonError {
+	payload := jsonError{
+		Message:    e.Message,
+		HTTPCode:   e.HTTPCode,
+		CustomCode: e.CustomCode,
+		Code:       e.Code,
+		Fields:     Fields(e),
+	}
+
+	if e.base != nil {
+		payload.Cause = e.base.Error()
+	}
+
+	if createdAt, ok := CreatedAt(e); ok {
+		payload.Timestamp = createdAt.Format(time.RFC3339Nano)
+	}
+
+	if requestID, ok := RequestID(e); ok {
+		payload.RequestID = requestID
+	}
+
+	if traceID, ok := TraceID(e); ok {
+		payload.TraceID = traceID
+	}
+
+	if spanID, ok := SpanID(e); ok {
+		payload.SpanID = spanID
+	}
+
+	if hint, ok := Hint(e); ok {
+		payload.Hint = hint
+	}
+
+	if reason, ok := Reason(e); ok {
+		payload.Reason = reason
+	}
+
+	if source, ok := Source(e); ok {
+		payload.Source = source
+	}
+
+	payload.Retryable = IsRetryable(e)
+
+	if attempt, ok := Attempt(e); ok {
+		payload.Attempt = attempt
+	}
+
+	if severity, ok := GetSeverity(e); ok {
+		payload.Severity = severity.String()
+	}
+
+	if category, ok := Category(e); ok {
+		payload.Category = category
+	}
+
+	payload.Tags = Tags(e)
+
+	if component, ok := Component(e); ok {
+		payload.Component = component
+	}
+
+	for _, detail := range Details(e) {
+		if marshaler, ok := detail.(json.Marshaler); ok {
+			payload.Details = append(payload.Details, marshaler)
+		}
+	}
+
+	return payload
+}
+
+// UnmarshalJSON implements json.Unmarshaler for the CustomError.
+// It reconstructs a CustomError from the object emitted by MarshalJSON.
+// As the type of the original base error is unknown, the cause is restored as a plain error
+// carrying the serialized message, and the details are restored as json.RawMessage values.
+// It returns an error if the timestamp or the severity cannot be parsed.
+func (e *CustomError) UnmarshalJSON(data []byte) error {
+	var payload struct {
+		jsonError
+		Details []json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	result := CustomError{
+		Message:    payload.Message,
+		HTTPCode:   payload.HTTPCode,
+		CustomCode: payload.CustomCode,
+		Code:       payload.Code,
+		CTX:        context.Background(),
+		Fields:     payload.Fields,
+		RequestID:  payload.RequestID,
+		TraceID:    payload.TraceID,
+		SpanID:     payload.SpanID,
+		Hint:       payload.Hint,
+		Reason:     payload.Reason,
+		Source:     payload.Source,
+		Retryable:  payload.Retryable,
+		Attempt:    payload.Attempt,
+		Category:   payload.Category,
+		Tags:       payload.Tags,
+		Component:  payload.Component,
+	}
+
+	if payload.Severity != "" {
+		severity, err := parseSeverity(payload.Severity)
+		if err != nil {
+			return err
+		}
+
+		result.Severity = severity
+	}
+
+	for _, detail := range payload.Details {
+		result.Details = append(result.Details, detail)
+	}
+
+	if payload.Cause != "" {
+		result.base = stderrors.New(payload.Cause)
+	}
+
+	if payload.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339Nano, payload.Timestamp)
+		if err != nil {
+			return err
+		}
+
+		result.Timestamp = timestamp
+	}
+
+	*e = result
+
+	return nil
+}
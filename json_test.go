@@ -0,0 +1,110 @@
+package errx
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"bare", NewCustom("boom"), `{"message":"boom"}`},
+		{"both codes", New("not found", WithHTTPCode(404), WithCustomCode(1001)), `{"message":"not found","http_code":404,"custom_code":1001}`},
+		{"wrapped cause", Wrap(errSentinel, "lookup failed", WithHTTPCode(500)), `{"message":"lookup failed","http_code":500,"cause":"sentinel"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.err)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 123, time.UTC)
+	original := Wrap(errSentinel, "lookup failed",
+		WithHTTPCode(404), WithCustomCode(1001), WithCode("users.not_found"),
+		WithFields(map[string]any{"user": "alice", "attempts": 3.0}),
+		withTimestampFrom(func() time.Time { return created }),
+		WithRequestID("req-1"), WithTraceID("trace-1"), WithSpanID("span-1"),
+		WithHint("check the id"), WithReason("USER_NOT_FOUND"), WithSource("users"),
+		WithRetryable(true), WithAttempt(3), WithSeverity(SeverityWarning), WithCategory("not_found"),
+		WithTags("db", "users"), WithComponent("repository"))
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var got CustomError
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	want := original.(*CustomError)
+	if got.Message != want.Message || got.HTTPCode != want.HTTPCode || got.CustomCode != want.CustomCode || got.Code != want.Code {
+		t.Errorf("codes = %q %d %d %q, want %q %d %d %q",
+			got.Message, got.HTTPCode, got.CustomCode, got.Code, want.Message, want.HTTPCode, want.CustomCode, want.Code)
+	}
+	if !maps.Equal(got.Fields, want.Fields) {
+		t.Errorf("Fields = %v, want %v", got.Fields, want.Fields)
+	}
+	if !got.Timestamp.Equal(created) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, created)
+	}
+	if got.RequestID != "req-1" || got.TraceID != "trace-1" || got.SpanID != "span-1" {
+		t.Errorf("IDs = %q %q %q, want req-1 trace-1 span-1", got.RequestID, got.TraceID, got.SpanID)
+	}
+	if got.Hint != "check the id" || got.Reason != "USER_NOT_FOUND" || got.Source != "users" {
+		t.Errorf("hint, reason, source = %q %q %q", got.Hint, got.Reason, got.Source)
+	}
+	if !got.Retryable || got.Attempt != 3 || got.Severity != SeverityWarning {
+		t.Errorf("retryable, attempt, severity = %t %d %v, want true 3 WARNING", got.Retryable, got.Attempt, got.Severity)
+	}
+	if got.Category != "not_found" || got.Component != "repository" {
+		t.Errorf("category, component = %q %q, want not_found repository", got.Category, got.Component)
+	}
+	if !slices.Equal(got.Tags, []string{"db", "users"}) {
+		t.Errorf("Tags = %v, want [db users]", got.Tags)
+	}
+	if got.Cause() == nil || got.Cause().Error() != "sentinel" {
+		t.Errorf("Cause() = %v, want a plain error reading sentinel", got.Cause())
+	}
+	if got.Error() != original.Error() {
+		t.Errorf("Error() = %q, want %q", got.Error(), original.Error())
+	}
+}
+
+func TestUnmarshalJSONInvalidTimestamp(t *testing.T) {
+	var got CustomError
+	if err := json.Unmarshal([]byte(`{"message":"boom","timestamp":"yesterday"}`), &got); err == nil {
+		t.Error("json.Unmarshal() with an invalid timestamp succeeded, want an error")
+	}
+}
+
+func TestJSONSeverity(t *testing.T) {
+	data, _ := json.Marshal(New("disk almost full", WithSeverity(SeverityCritical)))
+	if want := `{"message":"disk almost full","severity":"CRITICAL"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var got CustomError
+	if err := json.Unmarshal([]byte(`{"message":"boom","severity":"Severity(9)"}`), &got); err != nil || got.Severity != 9 {
+		t.Errorf("json.Unmarshal() of an unnamed severity = (%v, %v), want (Severity(9), nil)", got.Severity, err)
+	}
+	if err := json.Unmarshal([]byte(`{"message":"boom","severity":"loud"}`), &got); err == nil {
+		t.Error("json.Unmarshal() with an invalid severity succeeded, want an error")
+	}
+}
@@ -0,0 +1,76 @@
+package errx
+
+import (
+	"strings"
+	"sync"
+)
+
+// MessageBundle holds localized messages for error codes.
+// A MessageBundle is safe for concurrent use.
+type MessageBundle struct {
+	defaultLocale string
+
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+}
+
+// NewMessageBundle returns an empty MessageBundle that falls back to the given locale
+// when a message is missing for the requested one.
+func NewMessageBundle(defaultLocale string) *MessageBundle {
+	return &MessageBundle{
+		defaultLocale: defaultLocale,
+		messages:      make(map[string]map[string]string),
+	}
+}
+
+// Add sets the message for the given code in the given locale.
+func (b *MessageBundle) Add(code, locale, message string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.messages[code] == nil {
+		b.messages[code] = make(map[string]string)
+	}
+
+	b.messages[code][locale] = message
+}
+
+// Localize returns a copy of the first CustomError in err's chain with its message replaced by the
+// message registered for the error's code in the given language.
+// The language is looked up as is, then without its region (so "en-US" falls back to "en"),
+// and finally the default locale of the bundle is used.
+// If that CustomError is embedded in a ValidationError, the result is a copy of the ValidationError.
+// If err has no code or no message is found, err is returned unchanged.
+func (b *MessageBundle) Localize(err error, lang string) error {
+	code, ok := Code(err)
+	if !ok {
+		return err
+	}
+
+	message, ok := b.lookup(code, lang)
+	if !ok {
+		return err
+	}
+
+	return cloneFirstCustom(err, func(customErr *CustomError) {
+		customErr.Message = message
+	})
+}
+
+// lookup returns the message for the given code in the given language, applying the fallbacks
+// described in Localize.
+func (b *MessageBundle) lookup(code, lang string) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	messages := b.messages[code]
+
+	base, _, _ := strings.Cut(lang, "-")
+	for _, locale := range []string{lang, base, b.defaultLocale} {
+		if message, ok := messages[locale]; ok {
+			return message, true
+		}
+	}
+
+	return "", false
+}
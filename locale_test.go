@@ -0,0 +1,70 @@
+package errx
+
+import "testing"
+
+func TestLocalize(t *testing.T) {
+	bundle := NewMessageBundle("en")
+	bundle.Add("users.not_found", "en", "user not found")
+	bundle.Add("users.not_found", "fr", "utilisateur introuvable")
+
+	err := New("user missing", WithCode("users.not_found"), WithHTTPCode(404))
+
+	tests := []struct {
+		name string
+		lang string
+		want string
+	}{
+		{"present locale", "fr", "utilisateur introuvable"},
+		{"region fallback", "fr-CA", "utilisateur introuvable"},
+		{"missing locale", "de", "user not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localized := bundle.Localize(err, tt.lang)
+			if got := Message(localized); got != tt.want {
+				t.Errorf("Message() = %q, want %q", got, tt.want)
+			}
+			if code, _ := GetHTTPCode(localized); code != 404 {
+				t.Errorf("GetHTTPCode() = %d, want 404", code)
+			}
+		})
+	}
+
+	if Message(err) != "user missing" {
+		t.Error("Localize() modified the original error")
+	}
+}
+
+func TestLocalizeValidationError(t *testing.T) {
+	bundle := NewMessageBundle("en")
+	bundle.Add("signup.invalid", "fr", "inscription invalide")
+
+	err := NewValidation(map[string]string{"email": "required"}, WithCode("signup.invalid"))
+
+	localized := bundle.Localize(err, "fr")
+	if got := Message(localized); got != "inscription invalide" {
+		t.Errorf("Message() = %q, want %q", got, "inscription invalide")
+	}
+	if fields, ok := FieldErrors(localized); !ok || fields["email"] != "required" {
+		t.Errorf("FieldErrors() = (%v, %t), want the field errors", fields, ok)
+	}
+	if Message(err) != validationMessage {
+		t.Error("Localize() modified the original error")
+	}
+}
+
+func TestLocalizeWithoutCode(t *testing.T) {
+	bundle := NewMessageBundle("en")
+	bundle.Add("users.not_found", "en", "user not found")
+
+	err := New("boom", WithHTTPCode(500))
+	if got := bundle.Localize(err, "en"); got != err {
+		t.Errorf("Localize() = %v, want the error unchanged", got)
+	}
+
+	unknown := New("boom", WithCode("other"))
+	if got := bundle.Localize(unknown, "en"); got != unknown {
+		t.Errorf("Localize() of an unknown code = %v, want the error unchanged", got)
+	}
+}
@@ -0,0 +1,50 @@
+package errx
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Logger writes errors to an io.Writer as newline-delimited JSON, one object per error,
+// for example to stream the failures of a batch job while it keeps running.
+// It is safe for concurrent use, and each error is written with a single call to Write,
+// so lines from concurrent calls are never interleaved.
+type Logger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLogger returns a Logger that writes to w.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// Log writes err to the Logger as a single line of JSON.
+// Errors that implement json.Marshaler, such as CustomErrors, are serialized with MarshalJSON,
+// and any other error is serialized as an object holding its message.
+// A nil error is not written. It returns any error from the serialization or the write.
+func (l *Logger) Log(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var value any = jsonError{Message: err.Error()}
+	if _, ok := err.(json.Marshaler); ok {
+		value = err
+	}
+
+	line, marshalErr := json.Marshal(value)
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, writeErr := l.w.Write(line)
+
+	return writeErr
+}
@@ -0,0 +1,64 @@
+package errx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestLoggerConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+
+	const workers, perWorker = 8, 25
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range perWorker {
+				err := New(fmt.Sprintf("worker %d error %d", w, i), WithHTTPCode(500),
+					WithFields(map[string]any{"worker": w}))
+				if logErr := logger.Log(err); logErr != nil {
+					t.Errorf("Log() error = %v", logErr)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	lines := 0
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var entry map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		if entry["http_code"] != float64(500) {
+			t.Errorf("line %q has no HTTP code", scanner.Text())
+		}
+
+		lines++
+	}
+
+	if lines != workers*perWorker {
+		t.Errorf("logged %d lines, want %d", lines, workers*perWorker)
+	}
+}
+
+func TestLoggerPlainAndNil(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+
+	_ = logger.Log(nil)
+	_ = logger.Log(errSentinel)
+
+	if got, want := buf.String(), "{\"message\":\"sentinel\"}\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
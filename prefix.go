@@ -0,0 +1,56 @@
+package errx
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WithPrefix returns a Property that prefixes the message of an error with the given prefix in
+// brackets when it is rendered, as in "[billing] payment failed". The prefix is applied by Error
+// and Format only, so the Message field and the Message function keep the raw message.
+// When several prefixes are set on the same error, the last one applied is rendered first,
+// as in "[api] [billing] payment failed". The prefixes of an error with an empty message, such as
+// the layer added by Enrich, are rendered before the message of its base error, as in "[db] EOF".
+// If the error is a CustomError, it adds the prefix to the existing error.
+// Otherwise, it creates a new CustomError with the specified prefix.
+func WithPrefix(prefix string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.prefixes = append(customErr.prefixes, prefix)
+
+			return err
+		}
+
+		return &CustomError{
+			Message:  err.Error(),
+			prefixes: []string{prefix},
+		}
+	}
+}
+
+// renderedMessage returns the message of the CustomError preceded by its prefixes, outermost first.
+// The prefixes of an empty message are rendered without a trailing space.
+func (e *CustomError) renderedMessage() string {
+	if e.Message == "" {
+		return strings.TrimSuffix(e.renderedPrefixes(), " ")
+	}
+
+	return e.renderedPrefixes() + e.Message
+}
+
+// renderedPrefixes returns the prefixes of the CustomError in brackets, outermost first, each
+// followed by a space, or an empty string if it has none.
+func (e *CustomError) renderedPrefixes() string {
+	if len(e.prefixes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := len(e.prefixes) - 1; i >= 0; i-- {
+		b.WriteString("[" + e.prefixes[i] + "] ")
+	}
+
+	return b.String()
+}
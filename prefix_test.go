@@ -0,0 +1,43 @@
+package errx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWithPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"single", New("payment failed", WithPrefix("billing")), "[billing] payment failed"},
+		{"nested", New("payment failed", WithPrefix("billing"), WithPrefix("api")), "[api] [billing] payment failed"},
+		{"with base", Wrap(errSentinel, "payment failed", WithPrefix("billing")), "sentinel: [billing] payment failed"},
+		{"plain error", WithPrefix("billing")(errSentinel), "[billing] sentinel"},
+		{"enriched", Enrich(errSentinel, WithPrefix("db")), "[db] sentinel"},
+		{"empty message", New("", WithPrefix("db")), "[db]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if got := fmt.Sprintf("%v", tt.err); got != tt.want {
+				t.Errorf("%%v = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPrefixKeepsRawMessage(t *testing.T) {
+	err := New("payment failed", WithPrefix("billing"), WithHTTPCode(402))
+
+	if got := Message(err); got != "payment failed" {
+		t.Errorf("Message() = %q, want the raw message", got)
+	}
+	if got := err.(*CustomError).Message; got != "payment failed" {
+		t.Errorf("Message field = %q, want the raw message", got)
+	}
+}
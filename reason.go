@@ -0,0 +1,39 @@
+package errx
+
+import "github.com/pkg/errors"
+
+// WithReason returns a Property that sets the reason of an error.
+// A reason is a short machine-readable token such as "QUOTA_EXCEEDED", in SCREAMING_SNAKE_CASE,
+// which complements the numeric codes and the human-readable message.
+// If the error is a CustomError, it updates the Reason of the existing error.
+// Otherwise, it creates a new CustomError with the specified reason.
+func WithReason(reason string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Reason = reason
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Reason:  reason,
+		}
+	}
+}
+
+// Reason returns the reason of the outermost CustomError in err's chain that has one set.
+// The boolean result reports whether such a reason was found.
+func Reason(err error) (string, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Reason != "" {
+			return customErr.Reason, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return "", false
+}
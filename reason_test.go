@@ -0,0 +1,44 @@
+package errx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReason(t *testing.T) {
+	inner := New("quota exceeded", WithReason("QUOTA_EXCEEDED"))
+
+	tests := []struct {
+		name   string
+		err    error
+		want   string
+		wantOK bool
+	}{
+		{"unset", New("boom", WithHTTPCode(500)), "", false},
+		{"inner only", Wrap(inner, "upload failed"), "QUOTA_EXCEEDED", true},
+		{"outer wins", Wrap(inner, "upload failed", WithReason("UPLOAD_REJECTED")), "UPLOAD_REJECTED", true},
+		{"plain error", errSentinel, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Reason(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Reason() = (%q, %t), want (%q, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReasonJSON(t *testing.T) {
+	data, _ := json.Marshal(New("quota exceeded", WithHTTPCode(429), WithReason("QUOTA_EXCEEDED")))
+	if want := `{"message":"quota exceeded","http_code":429,"reason":"QUOTA_EXCEEDED"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	data, _ = json.Marshal(New("boom", WithHTTPCode(500)))
+	if strings.Contains(string(data), "reason") {
+		t.Errorf("json.Marshal() without a reason = %s, want no reason key", data)
+	}
+}
@@ -0,0 +1,53 @@
+package errx
+
+import (
+	"context"
+	"fmt"
+)
+
+// recoveredMessage is the message of the errors created by Recover from a panic value that is an error.
+const recoveredMessage = "recovered from panic"
+
+// Recover converts a panic into a CustomError and assigns it to *errPtr.
+// It must be called directly by defer, as in defer errx.Recover(&err, errx.WithHTTPCode(500)).
+// On a recovered panic, the error has the panic value as its message and a stack captured at
+// the point of the panic, and the given properties are applied to it. If the panic value is
+// an error, such as one raised by Must, it becomes the base error instead, and its codes and
+// context are carried forward as with Wrap.
+// If there was no panic, *errPtr is left unchanged.
+func Recover(errPtr *error, properties ...Property) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	var customErr *CustomError
+	if recoveredErr, ok := recovered.(error); ok {
+		customErr = newLayer(recoveredErr, recoveredMessage)
+	} else {
+		customErr = &CustomError{
+			Message: fmt.Sprint(recovered),
+			CTX:     context.Background(),
+		}
+	}
+
+	customErr.stack = panicCallers()
+
+	var result error = customErr
+	for _, property := range properties {
+		result = property(result)
+	}
+
+	if errPtr != nil {
+		*errPtr = result
+	}
+}
+
+// Must panics with the given error if it is not nil.
+// The panic value is the error itself, so Recover can restore it along with all its attributes.
+// It is meant for initialization code, in the manner of regexp.MustCompile.
+func Must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
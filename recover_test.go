@@ -0,0 +1,65 @@
+package errx
+
+import "testing"
+
+func panicking(msg string) (err error) {
+	defer Recover(&err, WithHTTPCode(500))
+
+	if msg != "" {
+		panic(msg)
+	}
+
+	return nil
+}
+
+func TestRecoverPanic(t *testing.T) {
+	err := panicking("boom")
+	if err == nil {
+		t.Fatal("panicking() = nil, want the recovered error")
+	}
+
+	if got := err.Error(); got != "boom" {
+		t.Errorf("Error() = %q, want %q", got, "boom")
+	}
+	if code, _ := GetHTTPCode(err); code != 500 {
+		t.Errorf("GetHTTPCode() = %d, want 500", code)
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	if err := panicking(""); err != nil {
+		t.Errorf("panicking() = %v, want nil", err)
+	}
+}
+
+func TestRecoverErrorValue(t *testing.T) {
+	err := func() (err error) {
+		defer Recover(&err)
+
+		Must(New("not found", WithHTTPCode(404)))
+
+		return nil
+	}()
+
+	if code, _ := GetHTTPCode(err); code != 404 {
+		t.Errorf("GetHTTPCode() = %d, want 404", code)
+	}
+	if got, want := err.Error(), "not found: "+recoveredMessage; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestMust(t *testing.T) {
+	Must(nil)
+
+	err := New("config missing", WithHTTPCode(500), WithCustomCode(1001))
+	defer func() {
+		recovered := recover()
+		if recovered != err {
+			t.Errorf("recovered %v, want the original error", recovered)
+		}
+	}()
+
+	Must(err)
+	t.Error("Must() did not panic")
+}
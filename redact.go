@@ -0,0 +1,90 @@
+package errx
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// redactedMessage is the message used by Redact for errors that are not CustomErrors.
+const redactedMessage = "internal error"
+
+// WithSensitiveFields returns a Property that marks the given field keys as sensitive,
+// so that Redact drops them.
+// If the error is a CustomError, the keys are added to those of the existing error.
+// Otherwise, it creates a new CustomError with the specified keys.
+func WithSensitiveFields(keys ...string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.sensitiveFields = append(customErr.sensitiveFields, keys...)
+
+			return err
+		}
+
+		return &CustomError{
+			Message:         err.Error(),
+			sensitiveFields: slices.Clone(keys),
+		}
+	}
+}
+
+// Redact returns an error that is safe to return to clients.
+// The result is a new CustomError keeping the message, HTTP code, custom code and code of the
+// first CustomError in err's chain, along with the fields collected from the chain except those
+// marked via WithSensitiveFields on any layer. The base error is dropped, so no cause is exposed.
+// If err is not and does not wrap a CustomError, the result only carries a generic message.
+// It returns nil for a nil error.
+func Redact(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var customErr *CustomError
+	if !errors.As(err, &customErr) {
+		return &CustomError{
+			Message: redactedMessage,
+			CTX:     context.Background(),
+		}
+	}
+
+	return &CustomError{
+		Message:    customErr.Message,
+		HTTPCode:   customErr.HTTPCode,
+		CustomCode: customErr.CustomCode,
+		Code:       customErr.Code,
+		CTX:        context.Background(),
+		Fields:     SafeFields(err),
+	}
+}
+
+// SafeFields is like Fields, but omits the fields marked via WithSensitiveFields on any layer of
+// err's chain. These are the fields kept by Redact, and the ones safe to expose to clients.
+// It returns nil if no fields remain.
+func SafeFields(err error) map[string]any {
+	fields := Fields(err)
+	for _, key := range sensitiveFields(err) {
+		delete(fields, key)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return fields
+}
+
+// sensitiveFields collects the field keys marked as sensitive on every CustomError in err's chain.
+func sensitiveFields(err error) []string {
+	var result []string
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		result = append(result, customErr.sensitiveFields...)
+
+		err = customErr.Unwrap()
+	}
+
+	return result
+}
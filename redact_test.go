@@ -0,0 +1,62 @@
+package errx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	inner := New("query failed", WithFields(map[string]any{"password": "hunter2"}), WithSensitiveFields("password"))
+	err := Wrap(Wrap(errSentinel, "db error"), "login failed",
+		WithHTTPCode(401), WithCustomCode(1001), WithCode("auth.failed"),
+		WithBase(inner), WithFields(map[string]any{"user": "alice", "token": "s3cr3t"}), WithSensitiveFields("token"))
+
+	redacted := Redact(err)
+
+	if got, want := redacted.Error(), "login failed"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	data, marshalErr := json.Marshal(redacted)
+	if marshalErr != nil {
+		t.Fatalf("json.Marshal() error = %v", marshalErr)
+	}
+	for _, leaked := range []string{"query failed", "sentinel", "hunter2", "s3cr3t", "cause"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("json.Marshal() = %s, contains %q", data, leaked)
+		}
+	}
+
+	want := `{"message":"login failed","http_code":401,"custom_code":1001,"code":"auth.failed","fields":{"user":"alice"}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestRedactPlainError(t *testing.T) {
+	if got := Redact(errSentinel).Error(); got != redactedMessage {
+		t.Errorf("Error() = %q, want %q", got, redactedMessage)
+	}
+	if Redact(nil) != nil {
+		t.Error("Redact(nil) is not nil")
+	}
+}
+
+func TestSafeFields(t *testing.T) {
+	inner := New("query failed", WithFields(map[string]any{"password": "hunter2", "table": "users"}), WithSensitiveFields("password"))
+	err := Wrap(inner, "login failed", WithFields(map[string]any{"token": "s3cr3t"}), WithSensitiveFields("token"))
+
+	want := map[string]any{"table": "users"}
+	if got := SafeFields(err); len(got) != 1 || got["table"] != want["table"] {
+		t.Errorf("SafeFields() = %v, want %v", got, want)
+	}
+	if got := Fields(err); len(got) != 3 {
+		t.Errorf("Fields() = %v, want the sensitive fields kept on the error", got)
+	}
+
+	onlySensitive := New("login failed", WithFields(map[string]any{"password": "hunter2"}), WithSensitiveFields("password"))
+	if got := SafeFields(onlySensitive); got != nil {
+		t.Errorf("SafeFields() with only sensitive fields = %v, want nil", got)
+	}
+}
@@ -0,0 +1,74 @@
+package errx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrDuplicateCode is returned by Registry.Register when a code is already registered.
+	ErrDuplicateCode = errors.New("errx: duplicate error code")
+
+	// ErrUnknownCode is the base of the errors returned by Registry.New for unregistered codes.
+	ErrUnknownCode = errors.New("errx: unknown error code")
+)
+
+// definition is a registered error code.
+type definition struct {
+	httpCode int
+	message  string
+}
+
+// Registry is a central place to declare error codes along with their HTTP code and default message.
+// The zero value is an empty registry ready to use. A Registry is safe for concurrent use.
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[int]definition
+}
+
+// Register declares the given custom code with its HTTP code and default message.
+// It returns an error wrapping ErrDuplicateCode if the code is already registered.
+func (r *Registry) Register(code int, httpCode int, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.definitions[code]; ok {
+		return errors.Wrapf(ErrDuplicateCode, "code %d", code)
+	}
+
+	if r.definitions == nil {
+		r.definitions = make(map[int]definition)
+	}
+
+	r.definitions[code] = definition{
+		httpCode: httpCode,
+		message:  message,
+	}
+
+	return nil
+}
+
+// New creates a new CustomError from the definition registered for the given code and applies
+// the given properties. The error has the registered message and HTTP code, and the given code
+// as its custom code.
+// If the code is not registered, it returns an internal server error wrapping ErrUnknownCode.
+func (r *Registry) New(code int, properties ...Property) error {
+	r.mu.RLock()
+	def, ok := r.definitions[code]
+	r.mu.RUnlock()
+
+	if !ok {
+		return &CustomError{
+			base:     ErrUnknownCode,
+			Message:  fmt.Sprintf("code %d", code),
+			HTTPCode: http.StatusInternalServerError,
+			CTX:      context.Background(),
+		}
+	}
+
+	return New(def.message, append([]Property{WithHTTPCode(def.httpCode), WithCustomCode(code)}, properties...)...)
+}
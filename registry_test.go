@@ -0,0 +1,48 @@
+package errx
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	var registry Registry
+	if err := registry.Register(1001, 404, "user not found"); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	err := registry.New(1001, WithFields(map[string]any{"user_id": 7}))
+	if got := err.Error(); got != "user not found" {
+		t.Errorf("Error() = %q, want %q", got, "user not found")
+	}
+	if code, _ := GetHTTPCode(err); code != 404 {
+		t.Errorf("GetHTTPCode() = %d, want 404", code)
+	}
+	if code, _ := GetCustomCode(err); code != 1001 {
+		t.Errorf("GetCustomCode() = %d, want 1001", code)
+	}
+	if Fields(err)["user_id"] != 7 {
+		t.Errorf("Fields() = %v, want the given fields", Fields(err))
+	}
+}
+
+func TestRegistryDuplicate(t *testing.T) {
+	var registry Registry
+	_ = registry.Register(1001, 404, "user not found")
+
+	if err := registry.Register(1001, 409, "conflict"); !Is(err, ErrDuplicateCode) {
+		t.Errorf("Register() of a duplicate code = %v, want ErrDuplicateCode", err)
+	}
+	if got := registry.New(1001).Error(); got != "user not found" {
+		t.Errorf("Error() after a duplicate registration = %q, want the first definition", got)
+	}
+}
+
+func TestRegistryUnknownCode(t *testing.T) {
+	var registry Registry
+
+	err := registry.New(2002)
+	if !Is(err, ErrUnknownCode) {
+		t.Errorf("New() of an unknown code = %v, want ErrUnknownCode", err)
+	}
+	if code, _ := GetHTTPCode(err); code != 500 {
+		t.Errorf("GetHTTPCode() = %d, want 500", code)
+	}
+}
@@ -0,0 +1,45 @@
+package errx
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Response is a consistent envelope for reporting an error to API clients.
+type Response struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Details []any  `json:"details,omitempty"`
+}
+
+// ToResponse builds the Response for err from its chain.
+// The status is resolved via ResolveHTTPCode, the code is the nearest code set via WithCode or
+// else the nearest custom code, the message is the one returned by Message, and the details are
+// the ones collected by Details.
+// An error that does not contain a CustomError gets the reason phrase of its status as the message,
+// so that its internal message is not exposed. A nil error gets the zero Response.
+func ToResponse(err error) Response {
+	if err == nil {
+		return Response{}
+	}
+
+	response := Response{
+		Status:  ResolveHTTPCode(err),
+		Details: Details(err),
+	}
+
+	if code, ok := Code(err); ok {
+		response.Code = code
+	} else if customCode, ok := GetCustomCode(err); ok {
+		response.Code = strconv.Itoa(customCode)
+	}
+
+	if _, ok := AsCustom(err); ok {
+		response.Message = Message(err)
+	} else {
+		response.Message = http.StatusText(response.Status)
+	}
+
+	return response
+}
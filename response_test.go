@@ -0,0 +1,54 @@
+package errx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestToResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		want     Response
+		wantJSON string
+	}{
+		{
+			"rich",
+			Wrap(New("quota exceeded", WithCustomCode(1001), WithDetails(quotaDetail{Limit: 10})), "upload rejected",
+				WithHTTPCode(429), WithCode("uploads.quota_exceeded")),
+			Response{Status: 429, Code: "uploads.quota_exceeded", Message: "upload rejected", Details: []any{quotaDetail{Limit: 10}}},
+			`{"status":429,"code":"uploads.quota_exceeded","message":"upload rejected","details":[{"limit":10}]}`,
+		},
+		{
+			"custom code only",
+			New("not found", WithHTTPCode(404), WithCustomCode(1001)),
+			Response{Status: 404, Code: "1001", Message: "not found"},
+			`{"status":404,"code":"1001","message":"not found"}`,
+		},
+		{
+			"bare",
+			errSentinel,
+			Response{Status: 500, Message: "Internal Server Error"},
+			`{"status":500,"message":"Internal Server Error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToResponse(tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToResponse() = %+v, want %+v", got, tt.want)
+			}
+
+			data, _ := json.Marshal(got)
+			if string(data) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.wantJSON)
+			}
+		})
+	}
+
+	if got := ToResponse(nil); !reflect.DeepEqual(got, Response{}) {
+		t.Errorf("ToResponse(nil) = %+v, want the zero Response", got)
+	}
+}
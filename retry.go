@@ -0,0 +1,86 @@
+package errx
+
+import "github.com/pkg/errors"
+
+// WithRetryable returns a Property that marks whether the operation that produced an error can be retried.
+// If the error is a CustomError, it updates the Retryable flag of the existing error.
+// Otherwise, it creates a new CustomError with the specified flag.
+func WithRetryable(retryable bool) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Retryable = retryable
+
+			return err
+		}
+
+		return &CustomError{
+			Message:   err.Error(),
+			Retryable: retryable,
+		}
+	}
+}
+
+// IsRetryable reports whether any CustomError in err's chain was marked as retryable.
+// An error that was never marked via WithRetryable is treated as non-retryable.
+func IsRetryable(err error) bool {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Retryable {
+			return true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return false
+}
+
+// Temporary reports whether the CustomError or any CustomError it wraps was marked as retryable,
+// like IsRetryable. It makes the CustomError recognized by code that checks for the Temporary
+// method of net.Error.
+// It returns false for a nil CustomError.
+func (e *CustomError) Temporary() bool {
+	if e == nil {
+		return false
+	}
+
+	return IsRetryable(e)
+}
+
+// WithAttempt returns a Property that records the attempt number of the operation that produced
+// an error, so that a retry loop can report how many attempts it took.
+// If the error is a CustomError, it updates the Attempt of the existing error, which allows each
+// retry to update the number on the error it re-wraps.
+// Otherwise, it creates a new CustomError with the specified attempt number.
+func WithAttempt(attempt int) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Attempt = attempt
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Attempt: attempt,
+		}
+	}
+}
+
+// Attempt returns the attempt number of the outermost CustomError in err's chain that has one set,
+// which is the most recent attempt.
+// The boolean result reports whether such an attempt number was found.
+func Attempt(err error) (int, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Attempt != 0 {
+			return customErr.Attempt, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return 0, false
+}
@@ -0,0 +1,77 @@
+package errx
+
+import "testing"
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"plain error", errSentinel, false},
+		{"unmarked", New("boom", WithHTTPCode(500)), false},
+		{"marked", New("boom", WithRetryable(true)), true},
+		{"inner mark", Wrap(New("timeout", WithRetryable(true)), "outer", WithRetryable(false)), true},
+		{"inner mark, unmarked outer", Wrap(New("timeout", WithRetryable(true)), "outer"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttemptAcrossRetries(t *testing.T) {
+	var err error
+	for attempt := 1; attempt <= 3; attempt++ {
+		if err == nil {
+			err = New("timeout", WithRetryable(true), WithAttempt(attempt))
+
+			continue
+		}
+
+		err = Wrap(err, "retry failed", WithAttempt(attempt))
+	}
+
+	if got, ok := Attempt(err); got != 3 || !ok {
+		t.Errorf("Attempt() = (%d, %t), want (3, true)", got, ok)
+	}
+	if !IsRetryable(err) {
+		t.Error("IsRetryable() = false, want true")
+	}
+	if _, ok := Attempt(New("boom", WithHTTPCode(500))); ok {
+		t.Error("Attempt() without an attempt = true, want false")
+	}
+}
+
+func TestTemporary(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"retryable", New("timeout", WithRetryable(true)), true},
+		{"retryable inner layer", Wrap(New("timeout", WithRetryable(true)), "fetch failed"), true},
+		{"unmarked", New("boom", WithHTTPCode(500)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te, ok := tt.err.(interface{ Temporary() bool })
+			if !ok {
+				t.Fatal("the error does not implement Temporary")
+			}
+			if got := te.Temporary(); got != tt.want {
+				t.Errorf("Temporary() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+
+	var nilErr *CustomError
+	if nilErr.Temporary() {
+		t.Error("Temporary() of a nil CustomError = true, want false")
+	}
+}
@@ -0,0 +1,85 @@
+package errx
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampler limits how often identical errors are reported, to reduce the noise of loops that
+// produce the same error many times. Errors are considered identical when they have the same
+// Fingerprint, and at most a fixed number of them is reported per interval.
+// It is safe for concurrent use.
+type Sampler struct {
+	limit    int
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*sampleWindow
+	lastSweep time.Time
+}
+
+// sampleWindow counts the reports of a fingerprint within an interval.
+type sampleWindow struct {
+	start time.Time
+	count int
+}
+
+// NewSampler returns a Sampler that reports at most limit errors with the same fingerprint in
+// each interval.
+func NewSampler(limit int, interval time.Duration) *Sampler {
+	return &Sampler{
+		limit:    limit,
+		interval: interval,
+		now:      time.Now,
+		windows:  make(map[string]*sampleWindow),
+	}
+}
+
+// ShouldReport reports whether err should be reported, which is the case for the first errors
+// with its fingerprint in the current interval, up to the limit of the Sampler. Each interval
+// starts with the first error of that fingerprint reported after the previous one has elapsed.
+// It returns false for a nil error.
+func (s *Sampler) ShouldReport(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	fingerprint := Fingerprint(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	window, ok := s.windows[fingerprint]
+	if !ok || now.Sub(window.start) >= s.interval {
+		window = &sampleWindow{start: now}
+		s.windows[fingerprint] = window
+	}
+
+	if window.count >= s.limit {
+		return false
+	}
+
+	window.count++
+
+	return true
+}
+
+// sweep removes the windows that have elapsed, at most once per interval, so that the Sampler
+// does not grow with every fingerprint it has seen.
+func (s *Sampler) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.interval {
+		return
+	}
+
+	for fingerprint, window := range s.windows {
+		if now.Sub(window.start) >= s.interval {
+			delete(s.windows, fingerprint)
+		}
+	}
+
+	s.lastSweep = now
+}
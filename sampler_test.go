@@ -0,0 +1,53 @@
+package errx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	sampler := NewSampler(2, time.Minute)
+	sampler.now = func() time.Time { return now }
+
+	timeout := New("timeout", WithHTTPCode(504))
+	other := New("not found", WithHTTPCode(404))
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		err     error
+		want    bool
+	}{
+		{"first", 0, timeout, true},
+		{"second", time.Second, timeout, true},
+		{"over the limit", time.Second, timeout, false},
+		{"other fingerprint", 0, other, true},
+		{"still suppressed", 30 * time.Second, timeout, false},
+		{"after the interval", 30 * time.Second, timeout, true},
+		{"new interval", time.Second, timeout, true},
+		{"over the limit again", time.Second, timeout, false},
+		{"nil", 0, nil, false},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if got := sampler.ShouldReport(step.err); got != step.want {
+			t.Errorf("%s: ShouldReport() = %t, want %t", step.name, got, step.want)
+		}
+	}
+}
+
+func TestSamplerSweep(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	sampler := NewSampler(1, time.Minute)
+	sampler.now = func() time.Time { return now }
+
+	sampler.ShouldReport(New("first", WithHTTPCode(500)))
+	now = now.Add(2 * time.Minute)
+	sampler.ShouldReport(New("second", WithHTTPCode(500)))
+
+	if len(sampler.windows) != 1 {
+		t.Errorf("Sampler has %d windows, want the elapsed one removed", len(sampler.windows))
+	}
+}
@@ -0,0 +1,87 @@
+package errx
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Severity is the level of seriousness of an error, used for alert routing.
+// The zero value means that no severity is set.
+type Severity int
+
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// String returns the name of the severity level.
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "DEBUG"
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityError:
+		return "ERROR"
+	case SeverityCritical:
+		return "CRITICAL"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// parseSeverity returns the severity level named as returned by Severity.String.
+func parseSeverity(name string) (Severity, error) {
+	for severity := SeverityDebug; severity <= SeverityCritical; severity++ {
+		if severity.String() == name {
+			return severity, nil
+		}
+	}
+
+	var level int
+	if _, err := fmt.Sscanf(name, "Severity(%d)", &level); err == nil {
+		return Severity(level), nil
+	}
+
+	return 0, fmt.Errorf("errx: unknown severity %q", name)
+}
+
+// WithSeverity returns a Property that sets the severity of an error.
+// If the error is a CustomError, it updates the Severity of the existing error.
+// Otherwise, it creates a new CustomError with the specified severity.
+func WithSeverity(severity Severity) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Severity = severity
+
+			return err
+		}
+
+		return &CustomError{
+			Message:  err.Error(),
+			Severity: severity,
+		}
+	}
+}
+
+// GetSeverity returns the highest severity set on any CustomError in err's chain.
+// The boolean result reports whether any severity was found.
+func GetSeverity(err error) (Severity, bool) {
+	var result Severity
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		result = max(result, customErr.Severity)
+
+		err = customErr.Unwrap()
+	}
+
+	return result, result != 0
+}
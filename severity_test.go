@@ -0,0 +1,33 @@
+package errx
+
+import "testing"
+
+func TestGetSeverityReturnsMax(t *testing.T) {
+	inner := New("inner", WithSeverity(SeverityCritical))
+	err := Wrap(Wrap(inner, "middle", WithSeverity(SeverityWarning)), "outer", WithSeverity(SeverityInfo))
+
+	if got, ok := GetSeverity(err); got != SeverityCritical || !ok {
+		t.Errorf("GetSeverity() = (%v, %t), want (CRITICAL, true)", got, ok)
+	}
+
+	if got, ok := GetSeverity(New("boom", WithHTTPCode(500))); got != 0 || ok {
+		t.Errorf("GetSeverity() without a severity = (%v, %t), want (0, false)", got, ok)
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	tests := map[Severity]string{
+		SeverityDebug:    "DEBUG",
+		SeverityInfo:     "INFO",
+		SeverityWarning:  "WARNING",
+		SeverityError:    "ERROR",
+		SeverityCritical: "CRITICAL",
+		Severity(42):     "Severity(42)",
+	}
+
+	for severity, want := range tests {
+		if got := severity.String(); got != want {
+			t.Errorf("Severity(%d).String() = %q, want %q", int(severity), got, want)
+		}
+	}
+}
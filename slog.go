@@ -0,0 +1,85 @@
+package errx
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer for the CustomError.
+// It returns a group holding the message, the HTTP and custom codes when set, the cause,
+// the nearest request, trace and span IDs and category, the innermost source, and the fields
+// collected from the whole chain as a nested group sorted by key, in which groups set via
+// WithGroup are nested groups in turn.
+// If a stack was captured via WithStack, it is included as a list of "file:line" entries.
+// It returns an empty group for a nil CustomError.
+func (e *CustomError) LogValue() slog.Value {
+	if e == nil {
+		return slog.GroupValue()
+	}
+
+	attrs := []slog.Attr{slog.String("message", e.Message)}
+
+	if e.HTTPCode != 0 {
+		attrs = append(attrs, slog.Int("http_code", e.HTTPCode))
+	}
+
+	if e.CustomCode != 0 {
+		attrs = append(attrs, slog.Int("custom_code", e.CustomCode))
+	}
+
+	if e.base != nil {
+		attrs = append(attrs, slog.String("cause", e.base.Error()))
+	}
+
+	if requestID, ok := RequestID(e); ok {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+
+	if traceID, ok := TraceID(e); ok {
+		attrs = append(attrs, slog.String("trace_id", traceID))
+	}
+
+	if spanID, ok := SpanID(e); ok {
+		attrs = append(attrs, slog.String("span_id", spanID))
+	}
+
+	if category, ok := Category(e); ok {
+		attrs = append(attrs, slog.String("category", category))
+	}
+
+	if source, ok := Source(e); ok {
+		attrs = append(attrs, slog.String("source", source))
+	}
+
+	if fields := Fields(e); len(fields) > 0 {
+		attrs = append(attrs, fieldsGroup("fields", fields))
+	}
+
+	if frames := e.StackTrace(); len(frames) > 0 {
+		stack := make([]string, 0, len(frames))
+		for _, frame := range frames {
+			stack = append(stack, fmt.Sprintf("%s:%d", frame.File, frame.Line))
+		}
+
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// fieldsGroup returns a group with the given key holding the given fields sorted by key,
+// rendering any nested map[string]any as a nested group.
+func fieldsGroup(key string, fields map[string]any) slog.Attr {
+	attrs := make([]any, 0, len(fields))
+	for _, fieldKey := range sortedKeys(fields) {
+		if group, ok := fields[fieldKey].(map[string]any); ok {
+			attrs = append(attrs, fieldsGroup(fieldKey, group))
+
+			continue
+		}
+
+		attrs = append(attrs, slog.Any(fieldKey, fields[fieldKey]))
+	}
+
+	return slog.Group(key, attrs...)
+}
@@ -0,0 +1,47 @@
+package errx
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLogValueJSONHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	err := Wrap(errSentinel, "lookup failed", WithHTTPCode(404), WithCustomCode(1001))
+	logger.Error("request failed", "error", err)
+
+	var entry struct {
+		Error map[string]any `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log line %q is not JSON: %v", buf.String(), err)
+	}
+
+	want := map[string]any{
+		"message":     "lookup failed",
+		"http_code":   float64(404),
+		"custom_code": float64(1001),
+		"cause":       "sentinel",
+	}
+	if len(entry.Error) != len(want) {
+		t.Errorf("error group = %v, want %v", entry.Error, want)
+	}
+	for key, value := range want {
+		if entry.Error[key] != value {
+			t.Errorf("error.%s = %v, want %v", key, entry.Error[key], value)
+		}
+	}
+}
+
+func TestLogValueOmitsUnsetCodes(t *testing.T) {
+	value := NewCustom("boom").LogValue()
+
+	attrs := value.Group()
+	if len(attrs) != 1 || attrs[0].Key != "message" {
+		t.Errorf("LogValue() = %v, want only the message", attrs)
+	}
+}
@@ -0,0 +1,41 @@
+package errx
+
+import "github.com/pkg/errors"
+
+// WithSource returns a Property that sets the source of an error, which is the service or module
+// that produced it, such as "billing" or "auth/session".
+// If the error is a CustomError, it updates the Source of the existing error.
+// Otherwise, it creates a new CustomError with the specified source.
+func WithSource(source string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Source = source
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Source:  source,
+		}
+	}
+}
+
+// Source returns the source of the innermost CustomError in err's chain that has one set,
+// which is the service or module where the error originated.
+// The boolean result reports whether such a source was found.
+func Source(err error) (string, bool) {
+	var result string
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.Source != "" {
+			result = customErr.Source
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return result, result != ""
+}
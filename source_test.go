@@ -0,0 +1,41 @@
+package errx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSourceInnermostWins(t *testing.T) {
+	err := Wrap(Wrap(New("query failed", WithSource("postgres")), "repo", WithSource("users/repo")), "handler", WithHTTPCode(500))
+
+	if got, ok := Source(err); got != "postgres" || !ok {
+		t.Errorf("Source() = (%q, %t), want (postgres, true)", got, ok)
+	}
+	if _, ok := Source(New("boom", WithHTTPCode(500))); ok {
+		t.Error("Source() without a source = true, want false")
+	}
+
+	var found bool
+	for _, attr := range err.(*CustomError).LogValue().Group() {
+		if attr.Key == "source" {
+			found = attr.Value.String() == "postgres"
+		}
+	}
+	if !found {
+		t.Error("LogValue() does not hold the innermost source")
+	}
+}
+
+func TestSourceJSONRoundTrip(t *testing.T) {
+	err := Wrap(New("query failed", WithSource("postgres")), "repo", WithSource("users/repo"))
+
+	data, _ := json.Marshal(err)
+
+	var decoded CustomError
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("json.Unmarshal() error = %v", unmarshalErr)
+	}
+	if got, ok := Source(&decoded); got != "postgres" || !ok {
+		t.Errorf("Source() after a round trip = (%q, %t), want (postgres, true)", got, ok)
+	}
+}
@@ -0,0 +1,270 @@
+package errx
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxStackDepth is the maximum number of frames recorded by WithStack.
+const maxStackDepth = 32
+
+// maxCallerDepth is the maximum number of frames inspected by WithCaller to find the call site.
+const maxCallerDepth = 8
+
+// pkgPath is the import path of this package, used to skip its frames when capturing a stack.
+const pkgPath = "github.com/hamidghavidel/errx"
+
+// WithStack returns a Property that records the call stack at the point it is applied.
+// Frames belonging to this package are skipped, so the first frame is the caller's call site.
+// If err's chain already has a stack, as reported by HasStack, it leaves the error unchanged,
+// so that re-wrapping an error does not record duplicate stacks; use WithForceStack to
+// record one anyway.
+// If the error is a CustomError, it updates the stack of the existing error.
+// Otherwise, it creates a new CustomError with the captured stack.
+func WithStack() Property {
+	return func(err error) error {
+		if HasStack(err) {
+			return err
+		}
+
+		return withStack(err, callers())
+	}
+}
+
+// WithForceStack returns a Property that records the call stack like WithStack, but does so
+// even if err's chain already has a stack.
+func WithForceStack() Property {
+	return func(err error) error {
+		return withStack(err, callers())
+	}
+}
+
+// WithStackDepth returns a Property that records the call stack like WithStack, but keeps at
+// most n frames, starting from the caller's call site. As frames belonging to this package are
+// skipped before the limit is applied, they never count towards it.
+// The stack is never deeper than the 32 frames recorded by WithStack, and if n is not positive,
+// it behaves exactly like WithStack.
+func WithStackDepth(n int) Property {
+	return func(err error) error {
+		if HasStack(err) {
+			return err
+		}
+
+		stack := callers()
+		if n > 0 && len(stack) > n {
+			stack = stack[:n:n]
+		}
+
+		return withStack(err, stack)
+	}
+}
+
+// WithNoStack returns a Property that marks an error as expected, such as a "not found" error
+// used for control flow, so that it does not carry a stack. It removes any stack already
+// captured on the error, for example by a property set via SetDefaultProperties, and makes
+// WithStack, WithForceStack and WithStackDepth leave the error unchanged when applied to it or
+// to any error wrapping it.
+// If the error is a CustomError, it updates the existing error.
+// Otherwise, it creates a new CustomError without a stack.
+func WithNoStack() Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.stack = nil
+			customErr.noStack = true
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			noStack: true,
+		}
+	}
+}
+
+// withStack sets the given stack on err if it is a CustomError.
+// Otherwise, it creates a new CustomError with the given stack.
+// If err's chain was marked via WithNoStack, it returns err unchanged.
+func withStack(err error, stack []uintptr) error {
+	if stackSuppressed(err) {
+		return err
+	}
+
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		customErr.stack = stack
+
+		return err
+	}
+
+	return &CustomError{
+		Message: err.Error(),
+		stack:   stack,
+	}
+}
+
+// stackSuppressed reports whether any CustomError in err's chain was marked via WithNoStack.
+func stackSuppressed(err error) bool {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.noStack {
+			return true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return false
+}
+
+// HasStack reports whether any error in err's chain has a stack, either captured via WithStack
+// or recorded by github.com/pkg/errors.
+func HasStack(err error) bool {
+	found := false
+	WalkChain(err, func(e error) bool {
+		switch e := e.(type) {
+		case *CustomError:
+			found = e != nil && len(e.stack) > 0
+		case interface{ StackTrace() errors.StackTrace }:
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// Frame is a single location in the source code.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// WithCaller returns a Property that records the call site at the point it is applied.
+// It is cheaper than WithStack, as it records a single frame.
+// Frames belonging to this package are skipped, so the recorded frame is the caller's call site.
+// If the error is a CustomError, it updates the caller of the existing error.
+// Otherwise, it creates a new CustomError with the recorded caller.
+func WithCaller() Property {
+	return func(err error) error {
+		pc := caller()
+
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.caller = pc
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			caller:  pc,
+		}
+	}
+}
+
+// Caller returns the call site recorded via WithCaller on the first CustomError in err's chain that has one.
+// The boolean result reports whether such a call site was found.
+func Caller(err error) (Frame, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if customErr.caller != 0 {
+			frame, _ := runtime.CallersFrames([]uintptr{customErr.caller}).Next()
+
+			return Frame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			}, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return Frame{}, false
+}
+
+// StackTrace returns the frames of the stack captured by WithStack, outermost call last.
+// It returns nil if no stack was captured or for a nil CustomError.
+func (e *CustomError) StackTrace() []runtime.Frame {
+	if e == nil || len(e.stack) == 0 {
+		return nil
+	}
+
+	var result []runtime.Frame
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		result = append(result, frame)
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+// callers returns the program counters of the current goroutine's stack,
+// with the leading frames that belong to this package removed.
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	pcs = pcs[:n]
+
+	for len(pcs) > 0 && isInternalFrame(pcs[0]) {
+		pcs = pcs[1:]
+	}
+
+	return pcs
+}
+
+// panicCallers is like callers, but also removes the leading runtime frames that handle a panic
+// and any frame of this package that raised it, so that the first frame is the caller's function
+// that panicked.
+func panicCallers() []uintptr {
+	pcs := callers()
+	for len(pcs) > 0 && (isRuntimeFrame(pcs[0]) || isInternalFrame(pcs[0])) {
+		pcs = pcs[1:]
+	}
+
+	return pcs
+}
+
+// caller returns the program counter of the first frame of the current goroutine's stack
+// that does not belong to this package, or 0 if there is none.
+func caller() uintptr {
+	pcs := make([]uintptr, maxCallerDepth)
+	n := runtime.Callers(2, pcs)
+	for _, pc := range pcs[:n] {
+		if !isInternalFrame(pc) {
+			return pc
+		}
+	}
+
+	return 0
+}
+
+// isInternalFrame reports whether the given program counter belongs to a function of this package.
+func isInternalFrame(pc uintptr) bool {
+	fn := runtime.FuncForPC(pc - 1)
+	if fn == nil {
+		return false
+	}
+
+	return strings.HasPrefix(fn.Name(), pkgPath+".")
+}
+
+// isRuntimeFrame reports whether the given program counter belongs to a function of the runtime package.
+func isRuntimeFrame(pc uintptr) bool {
+	fn := runtime.FuncForPC(pc - 1)
+	if fn == nil {
+		return false
+	}
+
+	return strings.HasPrefix(fn.Name(), "runtime.")
+}
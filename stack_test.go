@@ -0,0 +1,203 @@
+package errx_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hamidghavidel/errx"
+	"github.com/pkg/errors"
+)
+
+// The tests of this file are in an external package, so that the frames of the test functions
+// are seen by the stack capture the same way as the frames of any other caller.
+
+// testPkgPath is the import path of this external test package, which prefixes its function names.
+const testPkgPath = "github.com/hamidghavidel/errx_test"
+
+func TestWithStackTopFrameIsCaller(t *testing.T) {
+	err := errx.New("boom", errx.WithStack())
+
+	customErr, ok := errx.AsCustom(err)
+	if !ok {
+		t.Fatalf("err is %T, want *CustomError", err)
+	}
+
+	frames := customErr.StackTrace()
+	if len(frames) == 0 {
+		t.Fatal("StackTrace() is empty")
+	}
+
+	const want = testPkgPath + ".TestWithStackTopFrameIsCaller"
+	if got := frames[0].Function; got != want {
+		t.Errorf("top frame = %q, want %q", got, want)
+	}
+	if !strings.HasSuffix(frames[0].File, "stack_test.go") {
+		t.Errorf("top frame file = %q, want stack_test.go", frames[0].File)
+	}
+}
+
+func TestWithStackOnPlainError(t *testing.T) {
+	err := errx.Wrap(stderrors.New("sentinel"), "outer", errx.WithStack())
+
+	customErr, _ := errx.AsCustom(err)
+	if frames := customErr.StackTrace(); len(frames) == 0 || frames[0].Function != testPkgPath+".TestWithStackOnPlainError" {
+		t.Errorf("StackTrace() = %v, want the test function on top", frames)
+	}
+}
+
+func TestWithCallerRecordsCallSite(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	err := errx.New("boom", errx.WithCaller())
+
+	frame, ok := errx.Caller(err)
+	if !ok {
+		t.Fatal("Caller() = false, want true")
+	}
+	if frame.Line != line+1 {
+		t.Errorf("Line = %d, want %d", frame.Line, line+1)
+	}
+	if want := testPkgPath + ".TestWithCallerRecordsCallSite"; frame.Function != want {
+		t.Errorf("Function = %q, want %q", frame.Function, want)
+	}
+	if !strings.HasSuffix(frame.File, "stack_test.go") {
+		t.Errorf("File = %q, want stack_test.go", frame.File)
+	}
+
+	if _, ok := errx.Caller(errx.Wrap(err, "outer")); !ok {
+		t.Error("Caller() through a wrap = false, want true")
+	}
+	if _, ok := errx.Caller(errx.New("boom", errx.WithHTTPCode(500))); ok {
+		t.Error("Caller() without WithCaller = true, want false")
+	}
+}
+
+func TestWithStackOnlyOnce(t *testing.T) {
+	inner := errx.New("inner", errx.WithStack())
+	innerFrames := len(inner.(*errx.CustomError).StackTrace())
+
+	again := errx.Wrap(inner, "outer", errx.WithStack())
+	if !errx.HasStack(again) {
+		t.Error("HasStack() = false, want true")
+	}
+	if got := again.(*errx.CustomError).StackTrace(); got != nil {
+		t.Errorf("WithStack() on a chain with a stack recorded %d frames, want none", len(got))
+	}
+	if len(inner.(*errx.CustomError).StackTrace()) != innerFrames {
+		t.Error("WithStack() modified the stack of the inner error")
+	}
+
+	forced := errx.Wrap(inner, "outer", errx.WithForceStack())
+	if len(forced.(*errx.CustomError).StackTrace()) == 0 {
+		t.Error("WithForceStack() did not record a stack")
+	}
+}
+
+func TestHasStack(t *testing.T) {
+	if errx.HasStack(errx.New("boom", errx.WithHTTPCode(500))) {
+		t.Error("HasStack() without a stack = true, want false")
+	}
+	if !errx.HasStack(errors.New("boom")) {
+		t.Error("HasStack() of a github.com/pkg/errors error = false, want true")
+	}
+	if errx.HasStack(nil) {
+		t.Error("HasStack(nil) = true, want false")
+	}
+}
+
+// deepCall calls fn after nesting depth more calls.
+func deepCall(depth int, fn func() error) error {
+	if depth == 0 {
+		return fn()
+	}
+
+	return deepCall(depth-1, fn)
+}
+
+func TestWithStackDepth(t *testing.T) {
+	for _, depth := range []int{1, 3, 5} {
+		err := deepCall(10, func() error { return errx.New("boom", errx.WithStackDepth(depth)) })
+
+		frames := err.(*errx.CustomError).StackTrace()
+		if len(frames) != depth {
+			t.Errorf("WithStackDepth(%d) captured %d frames", depth, len(frames))
+		}
+		if len(frames) > 0 && !strings.HasPrefix(frames[0].Function, testPkgPath+".TestWithStackDepth") {
+			t.Errorf("WithStackDepth(%d) top frame = %q, want the call site", depth, frames[0].Function)
+		}
+	}
+
+	full := deepCall(10, func() error { return errx.New("boom", errx.WithStack()) }).(*errx.CustomError).StackTrace()
+	unlimited := deepCall(10, func() error { return errx.New("boom", errx.WithStackDepth(0)) }).(*errx.CustomError).StackTrace()
+	if len(unlimited) != len(full) {
+		t.Errorf("WithStackDepth(0) captured %d frames, want %d like WithStack", len(unlimited), len(full))
+	}
+}
+
+func TestWithNoStackUnderDefaultStack(t *testing.T) {
+	errx.SetDefaultProperties(errx.WithStack())
+	defer errx.SetDefaultProperties()
+
+	if !errx.HasStack(errx.New("boom", errx.WithHTTPCode(500))) {
+		t.Fatal("HasStack() with WithStack as a default = false, want true")
+	}
+
+	err := errx.New("not found", errx.WithHTTPCode(404), errx.WithNoStack())
+	if errx.HasStack(err) {
+		t.Error("HasStack() with WithNoStack = true, want false")
+	}
+	if frames := err.(*errx.CustomError).StackTrace(); len(frames) != 0 {
+		t.Errorf("StackTrace() has %d frames, want none", len(frames))
+	}
+
+	for _, property := range []errx.Property{errx.WithStack(), errx.WithForceStack(), errx.WithStackDepth(3)} {
+		if errx.HasStack(property(err)) {
+			t.Error("HasStack() after applying a stack property = true, want false")
+		}
+	}
+	if errx.HasStack(errx.Wrap(err, "lookup failed")) {
+		t.Error("HasStack() of a wrapping error = true, want false")
+	}
+}
+
+func TestFormatVerboseIncludesStack(t *testing.T) {
+	err := errx.Wrap(errx.New("inner", errx.WithStack()), "outer")
+
+	got := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(got, "outer\ninner\n") {
+		t.Errorf("%%+v = %q, want the message chain first", got)
+	}
+	if !strings.Contains(got, testPkgPath+".TestFormatVerboseIncludesStack\n\t") {
+		t.Errorf("%%+v = %q, want the stack frames of the inner layer", got)
+	}
+	if strings.Contains(fmt.Sprintf("%v", err), "\n") {
+		t.Errorf("%%v output is not a single line")
+	}
+}
+
+func TestDisplayStack(t *testing.T) {
+	err := errx.Wrap(errx.New("inner", errx.WithStack()), "outer", errx.WithHTTPCode(500))
+
+	lines := strings.Split(errx.Display(err), "\n")
+	if len(lines) < 3 || lines[0] != "outer [http 500]" || lines[1] != "  inner" {
+		t.Fatalf("Display() = %q, want the two layers first", lines)
+	}
+	if !strings.HasPrefix(lines[2], "    at "+testPkgPath+".TestDisplayStack (") {
+		t.Errorf("Display() line 3 = %q, want the top frame indented below the inner layer", lines[2])
+	}
+}
+
+func panicking() (err error) {
+	defer errx.Recover(&err, errx.WithHTTPCode(500))
+
+	panic("boom")
+}
+
+func TestRecoverStack(t *testing.T) {
+	frames := panicking().(*errx.CustomError).StackTrace()
+	if len(frames) == 0 || frames[0].Function != testPkgPath+".panicking" {
+		t.Errorf("StackTrace() = %v, want the panicking function on top", frames)
+	}
+}
@@ -0,0 +1,68 @@
+package errx
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// WithTags returns a Property that adds the given labels to an error.
+// If the error is a CustomError, the tags are merged with its existing tags.
+// Otherwise, it creates a new CustomError with the specified tags.
+// Tags are kept sorted and without duplicates.
+func WithTags(tags ...string) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Tags = mergeTags(customErr.Tags, tags)
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			Tags:    mergeTags(nil, tags),
+		}
+	}
+}
+
+// HasTag reports whether any CustomError in err's chain has the given tag.
+func HasTag(err error, tag string) bool {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if slices.Contains(customErr.Tags, tag) {
+			return true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return false
+}
+
+// Tags collects the tags of every CustomError in err's chain, sorted and without duplicates.
+// It returns nil if no tags are set.
+func Tags(err error) []string {
+	var result []string
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		result = mergeTags(result, customErr.Tags)
+
+		err = customErr.Unwrap()
+	}
+
+	return result
+}
+
+// mergeTags returns the sorted union of the given tags, without duplicates.
+func mergeTags(tags, other []string) []string {
+	if len(other) == 0 {
+		return tags
+	}
+
+	result := append(slices.Clone(tags), other...)
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
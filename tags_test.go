@@ -0,0 +1,33 @@
+package errx
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithTagsUnion(t *testing.T) {
+	err := New("boom", WithTags("transient", "pii"), WithTags("pii", "user-error"))
+
+	want := []string{"pii", "transient", "user-error"}
+	if got := Tags(err); !slices.Equal(got, want) {
+		t.Errorf("Tags() = %v, want %v", got, want)
+	}
+}
+
+func TestTagsAcrossChain(t *testing.T) {
+	err := Wrap(New("inner", WithTags("transient", "db")), "outer", WithTags("user-error", "db"))
+
+	want := []string{"db", "transient", "user-error"}
+	if got := Tags(err); !slices.Equal(got, want) {
+		t.Errorf("Tags() = %v, want %v", got, want)
+	}
+	if !HasTag(err, "transient") {
+		t.Error("HasTag(transient) = false, want true")
+	}
+	if HasTag(err, "pii") {
+		t.Error("HasTag(pii) = true, want false")
+	}
+	if got := Tags(New("boom", WithHTTPCode(500))); got != nil {
+		t.Errorf("Tags() without tags = %v, want nil", got)
+	}
+}
@@ -0,0 +1,51 @@
+package errx
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WithTimestamp returns a Property that records the current time as the creation time of an error.
+// If the error is a CustomError, it updates the Timestamp of the existing error.
+// Otherwise, it creates a new CustomError with the recorded time.
+func WithTimestamp() Property {
+	return withTimestampFrom(time.Now)
+}
+
+// withTimestampFrom is like WithTimestamp but reads the current time from the given clock.
+func withTimestampFrom(now func() time.Time) Property {
+	return func(err error) error {
+		timestamp := now()
+
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.Timestamp = timestamp
+
+			return err
+		}
+
+		return &CustomError{
+			Message:   err.Error(),
+			Timestamp: timestamp,
+		}
+	}
+}
+
+// CreatedAt returns the timestamp of the innermost CustomError in err's chain that has one set,
+// which is the time the original error was created.
+// The boolean result reports whether such a timestamp was found.
+func CreatedAt(err error) (time.Time, bool) {
+	var result time.Time
+
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if !customErr.Timestamp.IsZero() {
+			result = customErr.Timestamp
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return result, !result.IsZero()
+}
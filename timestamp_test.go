@@ -0,0 +1,22 @@
+package errx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithTimestampInjectedClock(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	wrapped := created.Add(time.Minute)
+
+	inner := New("inner", withTimestampFrom(func() time.Time { return created }))
+	err := Wrap(inner, "outer", withTimestampFrom(func() time.Time { return wrapped }))
+
+	if got, ok := CreatedAt(err); !got.Equal(created) || !ok {
+		t.Errorf("CreatedAt() = (%v, %t), want (%v, true)", got, ok, created)
+	}
+
+	if got, ok := CreatedAt(New("boom", WithHTTPCode(500))); !got.IsZero() || ok {
+		t.Errorf("CreatedAt() without a timestamp = (%v, %t), want zero and false", got, ok)
+	}
+}
@@ -0,0 +1,122 @@
+package errx
+
+import "maps"
+
+// ToMap returns the same attributes as MarshalJSON, under the same keys, as a map that can be fed
+// to any encoder. Values keep their Go types: fields and groups are returned as map[string]any,
+// details as []any, tags as []string and the field errors of a ValidationError as map[string]string.
+// Errors other than CustomErrors and ValidationErrors are returned as a map holding their message.
+// It returns nil for a nil error.
+func ToMap(err error) map[string]any {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *CustomError:
+		if e != nil {
+			return e.jsonPayload().toMap()
+		}
+	case *ValidationError:
+		if e != nil && e.CustomError != nil {
+			result := e.jsonPayload().toMap()
+			if len(e.Errors) > 0 {
+				result["errors"] = maps.Clone(e.Errors)
+			}
+
+			return result
+		}
+	}
+
+	return jsonError{Message: err.Error()}.toMap()
+}
+
+// toMap returns the serialized form as a map, omitting the empty attributes.
+func (p jsonError) toMap() map[string]any {
+	result := make(map[string]any)
+
+	if p.Message != "" {
+		result["message"] = p.Message
+	}
+
+	if p.HTTPCode != 0 {
+		result["http_code"] = p.HTTPCode
+	}
+
+	if p.CustomCode != 0 {
+		result["custom_code"] = p.CustomCode
+	}
+
+	if p.Code != "" {
+		result["code"] = p.Code
+	}
+
+	if p.Cause != "" {
+		result["cause"] = p.Cause
+	}
+
+	if len(p.Fields) > 0 {
+		result["fields"] = p.Fields
+	}
+
+	if p.Timestamp != "" {
+		result["timestamp"] = p.Timestamp
+	}
+
+	if p.RequestID != "" {
+		result["request_id"] = p.RequestID
+	}
+
+	if p.TraceID != "" {
+		result["trace_id"] = p.TraceID
+	}
+
+	if p.SpanID != "" {
+		result["span_id"] = p.SpanID
+	}
+
+	if len(p.Details) > 0 {
+		details := make([]any, 0, len(p.Details))
+		for _, detail := range p.Details {
+			details = append(details, detail)
+		}
+
+		result["details"] = details
+	}
+
+	if p.Hint != "" {
+		result["hint"] = p.Hint
+	}
+
+	if p.Reason != "" {
+		result["reason"] = p.Reason
+	}
+
+	if p.Source != "" {
+		result["source"] = p.Source
+	}
+
+	if p.Retryable {
+		result["retryable"] = p.Retryable
+	}
+
+	if p.Attempt != 0 {
+		result["attempt"] = p.Attempt
+	}
+
+	if p.Severity != "" {
+		result["severity"] = p.Severity
+	}
+
+	if p.Category != "" {
+		result["category"] = p.Category
+	}
+
+	if len(p.Tags) > 0 {
+		result["tags"] = p.Tags
+	}
+
+	if p.Component != "" {
+		result["component"] = p.Component
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package errx
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToMap(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := Wrap(errSentinel, "lookup failed",
+		WithHTTPCode(404), WithCustomCode(1001), WithCode("users.not_found"),
+		WithFields(map[string]any{"user": "alice"}),
+		WithGroup("db", map[string]any{"host": "db-1"}),
+		withTimestampFrom(func() time.Time { return created }),
+		WithRequestID("req-1"), WithTraceID("trace-1"), WithSpanID("span-1"),
+		WithDetails(quotaDetail{Limit: 10}),
+		WithHint("check the id"), WithReason("USER_NOT_FOUND"), WithSource("users"),
+		WithRetryable(true), WithAttempt(2), WithSeverity(SeverityError), WithCategory("not_found"),
+		WithTags("db"), WithComponent("repository"))
+
+	want := map[string]any{
+		"message":     "lookup failed",
+		"http_code":   404,
+		"custom_code": 1001,
+		"code":        "users.not_found",
+		"cause":       "sentinel",
+		"fields":      map[string]any{"user": "alice", "db": map[string]any{"host": "db-1"}},
+		"timestamp":   "2024-05-01T12:00:00Z",
+		"request_id":  "req-1",
+		"trace_id":    "trace-1",
+		"span_id":     "span-1",
+		"details":     []any{quotaDetail{Limit: 10}},
+		"hint":        "check the id",
+		"reason":      "USER_NOT_FOUND",
+		"source":      "users",
+		"retryable":   true,
+		"attempt":     2,
+		"severity":    "ERROR",
+		"category":    "not_found",
+		"tags":        []string{"db"},
+		"component":   "repository",
+	}
+	got := ToMap(err)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap() = %v, want %v", got, want)
+	}
+
+	// Once encoded, the map must hold the same attributes as MarshalJSON.
+	fromMap, _ := json.Marshal(got)
+	fromError, _ := json.Marshal(err)
+	var decodedMap, decodedError map[string]any
+	_ = json.Unmarshal(fromMap, &decodedMap)
+	_ = json.Unmarshal(fromError, &decodedError)
+	if !reflect.DeepEqual(decodedMap, decodedError) {
+		t.Errorf("json.Marshal(ToMap()) = %s, want the attributes of %s", fromMap, fromError)
+	}
+}
+
+func TestToMapOtherErrors(t *testing.T) {
+	validation := NewValidation(map[string]string{"email": "required"}, WithHTTPCode(422))
+	if got := ToMap(validation); !maps.Equal(got["errors"].(map[string]string), map[string]string{"email": "required"}) {
+		t.Errorf("ToMap() errors = %v, want the field errors", got["errors"])
+	}
+
+	if got, want := ToMap(errSentinel), map[string]any{"message": "sentinel"}; !maps.Equal(got, want) {
+		t.Errorf("ToMap() of a plain error = %v, want %v", got, want)
+	}
+	if got := ToMap(nil); got != nil {
+		t.Errorf("ToMap(nil) = %v, want nil", got)
+	}
+}
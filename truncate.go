@@ -0,0 +1,73 @@
+package errx
+
+import (
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// ellipsis is appended to messages truncated by Error.
+const ellipsis = "..."
+
+// MaxMessageLen is the maximum number of characters returned by the Error method of a CustomError.
+// Longer messages are truncated so that, including the ellipsis that ends them, they are exactly
+// MaxMessageLen characters long. A value of 0, the default,
+// disables truncation. It can be overridden per error with WithTruncate.
+// It is not safe to change concurrently with the creation of errors, so it should be configured at init time.
+var MaxMessageLen = 0
+
+// WithTruncate returns a Property that sets the maximum number of characters returned by the Error
+// method of an error, overriding MaxMessageLen. A value of 0 disables truncation for the error.
+// If the error is a CustomError, it updates the limit of the existing error.
+// Otherwise, it creates a new CustomError with the specified limit.
+func WithTruncate(n int) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if errors.As(err, &customErr) {
+			customErr.maxLen = &n
+
+			return err
+		}
+
+		return &CustomError{
+			Message: err.Error(),
+			maxLen:  &n,
+		}
+	}
+}
+
+// messageLimit returns the maximum number of characters returned by Error.
+func (e *CustomError) messageLimit() int {
+	if e.maxLen != nil {
+		return *e.maxLen
+	}
+
+	return MaxMessageLen
+}
+
+// truncate shortens s to at most n characters, the last three of which are an ellipsis, without
+// splitting multibyte characters. If n is too small to fit the ellipsis, s is cut to n characters
+// without one. It returns s unchanged if n is not positive or s is short enough.
+func truncate(s string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	suffix := ellipsis
+	keep := n - utf8.RuneCountInString(ellipsis)
+	if keep <= 0 {
+		suffix = ""
+		keep = n
+	}
+
+	count := 0
+	for i := range s {
+		if count == keep {
+			return s[:i] + suffix
+		}
+
+		count++
+	}
+
+	return s
+}
@@ -0,0 +1,54 @@
+package errx
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{"long ascii", strings.Repeat("a", 20), 10, "aaaaaaa..."},
+		{"multibyte", "héllo wörld ünïcode", 8, "héllo..."},
+		{"cjk", "错误信息太长了", 5, "错误..."},
+		{"short", "short", 10, "short"},
+		{"exact", "exact", 5, "exact"},
+		{"no room for the ellipsis", "abcdef", 2, "ab"},
+		{"disabled", strings.Repeat("a", 20), 0, strings.Repeat("a", 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.s, tt.n)
+			if got != tt.want {
+				t.Errorf("truncate() = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncate() = %q, which is not valid UTF-8", got)
+			}
+			if tt.n > 0 && utf8.RuneCountInString(got) > tt.n {
+				t.Errorf("truncate() = %q, longer than %d characters", got, tt.n)
+			}
+		})
+	}
+}
+
+func TestMaxMessageLen(t *testing.T) {
+	MaxMessageLen = 5
+	defer func() { MaxMessageLen = 0 }()
+
+	if got := New("abcdefgh", WithHTTPCode(500)).Error(); got != "ab..." {
+		t.Errorf("Error() = %q, want %q", got, "ab...")
+	}
+	if got := New("abcdefgh", WithTruncate(0)).Error(); got != "abcdefgh" {
+		t.Errorf("Error() with truncation disabled = %q, want %q", got, "abcdefgh")
+	}
+	if got := New("abcdefgh", WithTruncate(7)).Error(); got != "abcd..." {
+		t.Errorf("Error() with WithTruncate(7) = %q, want %q", got, "abcd...")
+	}
+}
@@ -0,0 +1,111 @@
+package errx
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// validationMessage is the message of the errors created by NewValidation.
+const validationMessage = "validation failed"
+
+// ValidationError is an error carrying a message per invalid field.
+// It embeds a CustomError, which it unwraps to, so the CustomError accessors and properties
+// apply to it as well.
+type ValidationError struct {
+	*CustomError
+	Errors map[string]string
+}
+
+// jsonValidationError is the serialized form of a ValidationError.
+type jsonValidationError struct {
+	jsonError
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// NewValidation creates a new ValidationError with the given field errors and applies the given properties.
+// The error has an HTTP code of 422 unless overridden by a property.
+func NewValidation(fields map[string]string, properties ...Property) error {
+	var result error = &ValidationError{
+		CustomError: &CustomError{
+			Message:  validationMessage,
+			HTTPCode: http.StatusUnprocessableEntity,
+			CTX:      context.Background(),
+		},
+		Errors: maps.Clone(fields),
+	}
+
+	for _, property := range properties {
+		result = property(result)
+	}
+
+	return result
+}
+
+// Unwrap returns the embedded CustomError.
+// It returns nil for a nil ValidationError.
+func (e *ValidationError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.CustomError
+}
+
+// Cause returns the embedded CustomError, like Unwrap, so that github.com/pkg/errors.Cause
+// traverses the same chain as errors.Unwrap.
+// It returns nil for a nil ValidationError.
+func (e *ValidationError) Cause() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.CustomError
+}
+
+// MarshalJSON implements json.Marshaler for the ValidationError.
+// It emits the same object as the embedded CustomError, with the field errors under an "errors" key.
+// A nil ValidationError, or one without an embedded CustomError, is serialized as null.
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	if e == nil || e.CustomError == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(jsonValidationError{
+		jsonError: e.jsonPayload(),
+		Errors:    e.Errors,
+	})
+}
+
+// FieldErrors returns a copy of the field errors of the first ValidationError in err's chain.
+// The boolean result reports whether such an error was found.
+func FieldErrors(err error) (map[string]string, bool) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return maps.Clone(validationErr.Errors), true
+	}
+
+	return nil, false
+}
+
+// cloneFirstCustom returns a copy of the first CustomError in err's chain, modified by the given
+// function, which must be found. If that CustomError is embedded in a ValidationError, the copy is
+// returned embedded in a copy of the ValidationError, so that its field errors are kept.
+func cloneFirstCustom(err error, modify func(*CustomError)) error {
+	customErr, _ := AsCustom(err)
+	result := customErr.Clone()
+	modify(result)
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && validationErr.CustomError == customErr {
+		return &ValidationError{
+			CustomError: result,
+			Errors:      maps.Clone(validationErr.Errors),
+		}
+	}
+
+	return result
+}
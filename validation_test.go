@@ -0,0 +1,43 @@
+package errx
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+)
+
+func TestValidationErrorJSON(t *testing.T) {
+	err := NewValidation(map[string]string{"name": "is required", "email": "is invalid"}, WithCustomCode(1001))
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("json.Marshal() error = %v", marshalErr)
+	}
+
+	want := `{"message":"validation failed","http_code":422,"custom_code":1001,"errors":{"email":"is invalid","name":"is required"}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestFieldErrorsThroughWrap(t *testing.T) {
+	fields := map[string]string{"name": "is required", "email": "is invalid"}
+	err := Wrap(NewValidation(fields), "signup failed")
+
+	got, ok := FieldErrors(err)
+	if !ok || !maps.Equal(got, fields) {
+		t.Errorf("FieldErrors() = (%v, %t), want (%v, true)", got, ok, fields)
+	}
+	if code, _ := GetHTTPCode(err); code != 422 {
+		t.Errorf("GetHTTPCode() = %d, want 422", code)
+	}
+
+	got["name"] = "changed"
+	if again, _ := FieldErrors(err); again["name"] != "is required" {
+		t.Error("modifying the result of FieldErrors changed the error")
+	}
+
+	if got, ok := FieldErrors(New("boom", WithHTTPCode(500))); got != nil || ok {
+		t.Errorf("FieldErrors() of a non-validation error = (%v, %t), want (nil, false)", got, ok)
+	}
+}
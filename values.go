@@ -0,0 +1,69 @@
+package errx
+
+import (
+	stderrors "errors"
+	"maps"
+
+	"github.com/pkg/errors"
+)
+
+// WithValue returns a Property that attaches a value to an error under the given key, in the
+// same way as context.WithValue. Values are meant for packages that extend errx, such as errxgrpc,
+// to attach attributes of their own: they are never serialized, logged or rendered, and, like
+// context keys, keys should be of an unexported type so that they cannot collide.
+// A value that is an error also makes the CustomError match it in As, so that interfaces it
+// implements, such as the GRPCStatus method used by gRPC, are found in the error's chain.
+// If the error is a CustomError, the value replaces any existing value under the same key.
+// Otherwise, it creates a new CustomError with the specified value.
+func WithValue(key, value any) Property {
+	return func(err error) error {
+		var customErr *CustomError
+		if !errors.As(err, &customErr) {
+			customErr = &CustomError{Message: err.Error()}
+			err = customErr
+		}
+
+		values := maps.Clone(customErr.values)
+		if values == nil {
+			values = make(map[any]any, 1)
+		}
+
+		values[key] = value
+		customErr.values = values
+
+		return err
+	}
+}
+
+// Value returns the value attached via WithValue under the given key to the outermost CustomError
+// in err's chain that has one.
+// The boolean result reports whether such a value was found.
+func Value(err error, key any) (any, bool) {
+	var customErr *CustomError
+	for errors.As(err, &customErr) {
+		if value, ok := customErr.values[key]; ok {
+			return value, true
+		}
+
+		err = customErr.Unwrap()
+	}
+
+	return nil, false
+}
+
+// As reports whether any value attached to the CustomError via WithValue is an error matching
+// target, in which case target is set to it. It allows errors.As to find the interfaces
+// implemented by such values.
+func (e *CustomError) As(target any) bool {
+	if e == nil {
+		return false
+	}
+
+	for _, value := range e.values {
+		if valueErr, ok := value.(error); ok && stderrors.As(valueErr, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package errx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type valueKey struct{}
+
+// statusError is an error value implementing an interface looked up via As.
+type statusError struct{ status int }
+
+func (e *statusError) Error() string { return "status" }
+
+func (e *statusError) Status() int { return e.status }
+
+func TestValue(t *testing.T) {
+	inner := New("not found", WithValue(valueKey{}, "inner"))
+
+	tests := []struct {
+		name   string
+		err    error
+		want   any
+		wantOK bool
+	}{
+		{"unset", New("boom", WithHTTPCode(500)), nil, false},
+		{"inner only", Wrap(inner, "lookup failed"), "inner", true},
+		{"outer wins", Wrap(inner, "lookup failed", WithValue(valueKey{}, "outer")), "outer", true},
+		{"plain error", errSentinel, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Value(tt.err, valueKey{})
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Value() = (%v, %t), want (%v, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	data, _ := json.Marshal(inner)
+	if want := `{"message":"not found"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	clone := inner.(*CustomError).Clone()
+	_ = WithValue(valueKey{}, "clone")(clone)
+	if got, _ := Value(inner, valueKey{}); got != "inner" {
+		t.Errorf("Value() after modifying a clone = %v, want inner", got)
+	}
+}
+
+func TestValueAs(t *testing.T) {
+	err := Wrap(New("unavailable", WithValue(valueKey{}, &statusError{status: 14})), "lookup failed")
+
+	var target interface{ Status() int }
+	if !As(err, &target) || target.Status() != 14 {
+		t.Errorf("As() = %v, want the attached error value", target)
+	}
+
+	var customErr *CustomError
+	if !As(err, &customErr) || customErr.Message != "lookup failed" {
+		t.Errorf("As(*CustomError) = %v, want the outer layer", customErr)
+	}
+}
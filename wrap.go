@@ -0,0 +1,83 @@
+package errx
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// wrapError is the error returned by Wrap for an error that is not and does not wrap a CustomError
+// when there are no properties to apply.
+// It behaves exactly like the error returned by errors.Wrap: Error returns the message followed
+// by the message of the wrapped error, Cause and Unwrap return the wrapped error, and the stack
+// recorded at the call to Wrap is exposed through StackTrace and printed by the %+v verb.
+// Unlike errors.Wrap, it is constructed with a single allocation, as the message, the wrapped error
+// and the program counters are held by the same value.
+type wrapError struct {
+	cause error
+	msg   string
+	pcs   [maxStackDepth]uintptr
+	n     int
+}
+
+// newWrapError returns a wrapError wrapping err with the given message.
+// It must be called directly by Wrap, so that the recorded stack starts at the same frame as the
+// stack recorded by errors.Wrap.
+func newWrapError(err error, msg string) *wrapError {
+	result := &wrapError{
+		cause: err,
+		msg:   msg,
+	}
+	result.n = runtime.Callers(2, result.pcs[:])
+
+	return result
+}
+
+// Error returns the message followed by the message of the wrapped error.
+func (e *wrapError) Error() string {
+	return e.msg + ": " + e.cause.Error()
+}
+
+// Cause returns the wrapped error, which allows github.com/pkg/errors.Cause to traverse it.
+func (e *wrapError) Cause() error {
+	return e.cause
+}
+
+// Unwrap returns the wrapped error.
+func (e *wrapError) Unwrap() error {
+	return e.cause
+}
+
+// StackTrace returns the stack recorded at the call to Wrap, in the form used by github.com/pkg/errors.
+func (e *wrapError) StackTrace() errors.StackTrace {
+	frames := make(errors.StackTrace, e.n)
+	for i, pc := range e.pcs[:e.n] {
+		frames[i] = errors.Frame(pc)
+	}
+
+	return frames
+}
+
+// Format implements fmt.Formatter like the errors returned by errors.Wrap.
+// The %s and %v verbs print the same one-line form as Error, and %q prints it quoted.
+// The %+v verb prints the wrapped error in the same form, followed by the message and the stack.
+func (e *wrapError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = fmt.Fprintf(s, "%+v\n", e.cause)
+			_, _ = io.WriteString(s, e.msg)
+			e.StackTrace().Format(s, verb)
+
+			return
+		}
+
+		fallthrough
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	}
+}
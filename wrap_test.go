@@ -0,0 +1,125 @@
+package errx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestWrapPlainErrorMatchesPkgErrors(t *testing.T) {
+	err := Wrap(io.EOF, "read failed")
+	want := errors.Wrap(io.EOF, "read failed")
+
+	if err.Error() != want.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), want.Error())
+	}
+	for _, verb := range []string{"%s", "%v", "%q"} {
+		if got, want := fmt.Sprintf(verb, err), fmt.Sprintf(verb, want); got != want {
+			t.Errorf("%s = %q, want %q", verb, got, want)
+		}
+	}
+	if errors.Cause(err) != io.EOF || !Is(err, io.EOF) {
+		t.Error("the wrapped error is not reachable through Cause and Is")
+	}
+	if !HasStack(err) {
+		t.Error("HasStack() = false, want true")
+	}
+
+	// As errors.Wrap records the stack from its caller, the stack starts at Wrap itself.
+	frames := err.(interface{ StackTrace() errors.StackTrace }).StackTrace()
+	wantFrames := want.(interface{ StackTrace() errors.StackTrace }).StackTrace()
+	if len(frames) != len(wantFrames)+1 {
+		t.Fatalf("StackTrace() has %d frames, want %d", len(frames), len(wantFrames)+1)
+	}
+	if got := fmt.Sprintf("%n", frames[0]); got != "Wrap" {
+		t.Errorf("frame 0 = %s, want Wrap", got)
+	}
+	for i := range wantFrames {
+		if got, want := fmt.Sprintf("%n", frames[i+1]), fmt.Sprintf("%n", wantFrames[i]); got != want {
+			t.Errorf("frame %d = %s, want %s", i+1, got, want)
+		}
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(verbose, "EOF\nread failed\ngithub.com/hamidghavidel/errx.Wrap\n\t") {
+		t.Errorf("%%+v = %q, want the wrapped error, the message and the stack", verbose)
+	}
+	if !strings.Contains(verbose, "errx.TestWrapPlainErrorMatchesPkgErrors\n\t") {
+		t.Errorf("%%+v = %q, want the test function in the stack", verbose)
+	}
+}
+
+func TestWrapAllocations(t *testing.T) {
+	custom := New("not found", WithHTTPCode(404))
+	wrapped := fmt.Errorf("x: %w", custom)
+
+	tests := []struct {
+		name string
+		fn   func()
+		want float64
+	}{
+		{"plain error", func() { _ = Wrap(io.EOF, "read failed") }, 1},
+		{"custom error", func() { _ = Wrap(custom, "lookup failed") }, 1},
+		{"AsCustom of a plain error", func() { _, _ = AsCustom(io.EOF) }, 0},
+		{"AsCustom of a wrapped custom error", func() { _, _ = AsCustom(wrapped) }, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testing.AllocsPerRun(100, tt.fn); got > tt.want {
+				t.Errorf("allocations = %v, want at most %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkWrap(b *testing.B) {
+	custom := New("not found", WithHTTPCode(404))
+
+	b.Run("plain", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = Wrap(io.EOF, "read failed")
+		}
+	})
+
+	b.Run("pkg", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = errors.Wrap(io.EOF, "read failed")
+		}
+	})
+
+	b.Run("custom", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = Wrap(custom, "lookup failed")
+		}
+	})
+
+	b.Run("properties", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = Wrap(io.EOF, "read failed", WithHTTPCode(500))
+		}
+	})
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.Run("plain", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = New("boom")
+		}
+	})
+
+	b.Run("properties", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = New("boom", WithHTTPCode(500))
+		}
+	})
+}